@@ -0,0 +1,310 @@
+package asset
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"sort"
+
+	"github.com/nasrul21/go-webflow/client"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+)
+
+type Asset interface {
+	GetList(siteID string) ([]model.Asset, *common.Error)
+	GetListWithContext(ctx context.Context, siteID string) ([]model.Asset, *common.Error)
+	Get(assetID string) (*model.Asset, *common.Error)
+	GetWithContext(ctx context.Context, assetID string) (*model.Asset, *common.Error)
+	Update(assetID string, req model.UpdateAssetRequest) (*model.Asset, *common.Error)
+	UpdateWithContext(ctx context.Context, assetID string, req model.UpdateAssetRequest) (*model.Asset, *common.Error)
+	UpdateAltText(assetID string, altText string) (*model.Asset, *common.Error)
+	UpdateAltTextWithContext(ctx context.Context, assetID string, altText string) (*model.Asset, *common.Error)
+	Move(assetID string, folderID string) (*model.Asset, *common.Error)
+	MoveWithContext(ctx context.Context, assetID string, folderID string) (*model.Asset, *common.Error)
+	GetFolderList(siteID string) ([]model.AssetFolder, *common.Error)
+	GetFolderListWithContext(ctx context.Context, siteID string) ([]model.AssetFolder, *common.Error)
+	GetFolder(folderID string) (*model.AssetFolder, *common.Error)
+	GetFolderWithContext(ctx context.Context, folderID string) (*model.AssetFolder, *common.Error)
+	CreateFolder(siteID string, req model.CreateAssetFolderRequest) (*model.AssetFolder, *common.Error)
+	CreateFolderWithContext(ctx context.Context, siteID string, req model.CreateAssetFolderRequest) (*model.AssetFolder, *common.Error)
+	Create(siteID string, req model.CreateAssetRequest) (*model.AssetUpload, *common.Error)
+	CreateWithContext(ctx context.Context, siteID string, req model.CreateAssetRequest) (*model.AssetUpload, *common.Error)
+	Upload(siteID string, fileName string, content io.Reader) (*model.Asset, *common.Error)
+	UploadWithContext(ctx context.Context, siteID string, fileName string, content io.Reader) (*model.Asset, *common.Error)
+	UploadOnce(siteID string, fileName string, content io.Reader, index Index) (*model.Asset, bool, *common.Error)
+	UploadOnceWithContext(ctx context.Context, siteID string, fileName string, content io.Reader, index Index) (*model.Asset, bool, *common.Error)
+	WithOptimizer(optimizer *Optimizer) Asset
+}
+
+type AssetImpl struct {
+	Opt    *common.Option
+	Client client.Client
+	// HttpClient performs the upload to the presigned target, which is not
+	// part of the Webflow API and takes a multipart body.
+	HttpClient *http.Client
+	// Optimizer, when set, processes images before they are hashed and
+	// uploaded.
+	Optimizer *Optimizer
+}
+
+func New(opt *common.Option, apiClient client.Client) Asset {
+	httpClient := &http.Client{}
+	if impl, ok := apiClient.(*client.ClientImpl); ok && impl.HttpClient != nil {
+		httpClient = impl.HttpClient
+	}
+
+	return &AssetImpl{
+		Opt:        opt,
+		Client:     apiClient,
+		HttpClient: httpClient,
+	}
+}
+
+func (a *AssetImpl) WithOptimizer(optimizer *Optimizer) Asset {
+	a.Optimizer = optimizer
+	return a
+}
+
+func (a *AssetImpl) GetList(siteID string) ([]model.Asset, *common.Error) {
+	return a.GetListWithContext(context.Background(), siteID)
+}
+
+func (a *AssetImpl) GetListWithContext(ctx context.Context, siteID string) ([]model.Asset, *common.Error) {
+	var response model.AssetList
+	var header http.Header
+
+	err := a.Client.Call(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/sites/%s/assets", a.Opt.BaseURL, siteID),
+		a.Opt.ApiKey,
+		header,
+		nil,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Assets, nil
+}
+
+func (a *AssetImpl) Get(assetID string) (*model.Asset, *common.Error) {
+	return a.GetWithContext(context.Background(), assetID)
+}
+
+func (a *AssetImpl) GetWithContext(ctx context.Context, assetID string) (*model.Asset, *common.Error) {
+	var response model.Asset
+	var header http.Header
+
+	err := a.Client.Call(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/assets/%s", a.Opt.BaseURL, assetID),
+		a.Opt.ApiKey,
+		header,
+		nil,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (a *AssetImpl) Update(assetID string, req model.UpdateAssetRequest) (*model.Asset, *common.Error) {
+	return a.UpdateWithContext(context.Background(), assetID, req)
+}
+
+func (a *AssetImpl) UpdateWithContext(ctx context.Context, assetID string, req model.UpdateAssetRequest) (*model.Asset, *common.Error) {
+	var response model.Asset
+	var header http.Header
+
+	err := a.Client.Call(
+		ctx,
+		http.MethodPatch,
+		fmt.Sprintf("%s/assets/%s", a.Opt.BaseURL, assetID),
+		a.Opt.ApiKey,
+		header,
+		req,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (a *AssetImpl) UpdateAltText(assetID string, altText string) (*model.Asset, *common.Error) {
+	return a.UpdateAltTextWithContext(context.Background(), assetID, altText)
+}
+
+func (a *AssetImpl) UpdateAltTextWithContext(ctx context.Context, assetID string, altText string) (*model.Asset, *common.Error) {
+	return a.UpdateWithContext(ctx, assetID, model.UpdateAssetRequest{AltText: &altText})
+}
+
+func (a *AssetImpl) Create(siteID string, req model.CreateAssetRequest) (*model.AssetUpload, *common.Error) {
+	return a.CreateWithContext(context.Background(), siteID, req)
+}
+
+func (a *AssetImpl) CreateWithContext(ctx context.Context, siteID string, req model.CreateAssetRequest) (*model.AssetUpload, *common.Error) {
+	var response model.AssetUpload
+	var header http.Header
+
+	err := a.Client.Call(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("%s/sites/%s/assets", a.Opt.BaseURL, siteID),
+		a.Opt.ApiKey,
+		header,
+		req,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (a *AssetImpl) Upload(siteID string, fileName string, content io.Reader) (*model.Asset, *common.Error) {
+	return a.UploadWithContext(context.Background(), siteID, fileName, content)
+}
+
+// UploadWithContext creates the asset metadata from the MD5 hash of content
+// and then uploads content to the presigned target returned by Webflow.
+func (a *AssetImpl) UploadWithContext(ctx context.Context, siteID string, fileName string, content io.Reader) (*model.Asset, *common.Error) {
+	fileName, data, err := a.read(fileName, content)
+	if err != nil {
+		return nil, common.FromGoErr(err)
+	}
+
+	return a.upload(ctx, siteID, fileName, data, Hash(data))
+}
+
+func (a *AssetImpl) UploadOnce(siteID string, fileName string, content io.Reader, index Index) (*model.Asset, bool, *common.Error) {
+	return a.UploadOnceWithContext(context.Background(), siteID, fileName, content, index)
+}
+
+// UploadOnceWithContext uploads content only when index has no asset with the
+// same content hash, and reports whether a new asset was uploaded. Newly
+// uploaded assets are added to index.
+func (a *AssetImpl) UploadOnceWithContext(ctx context.Context, siteID string, fileName string, content io.Reader, index Index) (*model.Asset, bool, *common.Error) {
+	fileName, data, err := a.read(fileName, content)
+	if err != nil {
+		return nil, false, common.FromGoErr(err)
+	}
+
+	hash := Hash(data)
+	if existing, ok := index.Lookup(hash); ok {
+		return existing, false, nil
+	}
+
+	uploaded, wfErr := a.upload(ctx, siteID, fileName, data, hash)
+	if wfErr != nil {
+		return nil, false, wfErr
+	}
+
+	if err := index.Add(hash, *uploaded); err != nil {
+		return uploaded, true, common.FromGoErr(err)
+	}
+
+	return uploaded, true, nil
+}
+
+func (a *AssetImpl) read(fileName string, content io.Reader) (string, []byte, error) {
+	data, err := ioutil.ReadAll(content)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if a.Optimizer == nil {
+		return fileName, data, nil
+	}
+
+	return a.Optimizer.Optimize(fileName, data)
+}
+
+func (a *AssetImpl) upload(ctx context.Context, siteID string, fileName string, data []byte, hash string) (*model.Asset, *common.Error) {
+	upload, err := a.CreateWithContext(ctx, siteID, model.CreateAssetRequest{
+		FileName: fileName,
+		FileHash: hash,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.uploadContent(ctx, upload, fileName, data); err != nil {
+		return nil, err
+	}
+
+	if upload.FileHash == "" {
+		upload.FileHash = hash
+	}
+
+	return &upload.Asset, nil
+}
+
+func (a *AssetImpl) uploadContent(ctx context.Context, upload *model.AssetUpload, fileName string, data []byte) *common.Error {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	keys := make([]string, 0, len(upload.UploadDetails))
+	for key := range upload.UploadDetails {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := writer.WriteField(key, upload.UploadDetails[key]); err != nil {
+			return common.FromGoErr(err)
+		}
+	}
+
+	// The file must be the last field of the form for the presigned POST to
+	// accept it.
+	part, err := writer.CreateFormFile("file", fileName)
+	if err != nil {
+		return common.FromGoErr(err)
+	}
+	if _, err := part.Write(data); err != nil {
+		return common.FromGoErr(err)
+	}
+	if err := writer.Close(); err != nil {
+		return common.FromGoErr(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, upload.UploadURL, body)
+	if err != nil {
+		return common.FromGoErr(err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := a.HttpClient.Do(req)
+	if err != nil {
+		return common.FromGoErr(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return common.FromGoErr(err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &common.Error{
+			Code:    resp.StatusCode,
+			Err:     common.UploadErrCode,
+			Message: string(respBody),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,338 @@
+package asset_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nasrul21/go-webflow"
+	"github.com/nasrul21/go-webflow/asset"
+	"github.com/nasrul21/go-webflow/client/mock"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/stretchr/testify/assert"
+)
+
+const siteID = "580e63e98c9a982ac9b8b741"
+
+type upload struct {
+	fields   map[string]string
+	fileName string
+	content  string
+}
+
+func newUploadServer(status int, received *upload) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		received.fields = map[string]string{}
+		for key, values := range r.MultipartForm.Value {
+			received.fields[key] = values[0]
+		}
+
+		file, header, err := r.FormFile("file")
+		if err == nil {
+			content, _ := ioutil.ReadAll(file)
+			received.fileName = header.Filename
+			received.content = string(content)
+		}
+
+		w.WriteHeader(status)
+		if status != http.StatusCreated {
+			w.Write([]byte("<Error><Code>AccessDenied</Code></Error>"))
+		}
+	}))
+}
+
+func TestUpload(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	var received upload
+	okServer := newUploadServer(http.StatusCreated, &received)
+	defer okServer.Close()
+	deniedServer := newUploadServer(http.StatusForbidden, &received)
+	defer deniedServer.Close()
+
+	uploadURL := okServer.URL
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		resultString := fmt.Sprintf(`{
+			"id": "63e5889e7fe4eafa7384cea4",
+			"contentType": "image/png",
+			"hostedUrl": "https://uploads-ssl.webflow.com/580e63e98c9a982ac9b8b741/63e5889e7fe4eafa7384cea4_logo.png",
+			"originalFileName": "logo.png",
+			"uploadUrl": %q,
+			"uploadDetails": {
+				"acl": "public-read",
+				"key": "580e63e98c9a982ac9b8b741/63e5889e7fe4eafa7384cea4_logo.png",
+				"success_action_status": "201"
+			}
+		}`, uploadURL)
+
+		_ = json.Unmarshal([]byte(resultString), &result)
+
+		return nil
+	}
+
+	createRequest := model.CreateAssetRequest{
+		FileName: "logo.png",
+		FileHash: "9e107d9d372bb6826bd81d3542a419d6",
+	}
+
+	testcases := []struct {
+		desc            string
+		uploadURL       string
+		mockClosure     func()
+		expectedRes     *model.Asset
+		expectedErrCode int
+	}{
+		{
+			desc:      "should upload asset",
+			uploadURL: okServer.URL,
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodPost,
+					fmt.Sprintf("%s/sites/%s/assets", wf.Opt.BaseURL, siteID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					createRequest,
+					&model.AssetUpload{},
+				).Return(nil).Once()
+			},
+			expectedRes: &model.Asset{
+				ID:               "63e5889e7fe4eafa7384cea4",
+				ContentType:      "image/png",
+				HostedURL:        "https://uploads-ssl.webflow.com/580e63e98c9a982ac9b8b741/63e5889e7fe4eafa7384cea4_logo.png",
+				OriginalFileName: "logo.png",
+				FileHash:         "9e107d9d372bb6826bd81d3542a419d6",
+			},
+		},
+		{
+			desc:      "should return error when upload is rejected",
+			uploadURL: deniedServer.URL,
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodPost,
+					fmt.Sprintf("%s/sites/%s/assets", wf.Opt.BaseURL, siteID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					createRequest,
+					&model.AssetUpload{},
+				).Return(nil).Once()
+			},
+			expectedRes:     nil,
+			expectedErrCode: http.StatusForbidden,
+		},
+		{
+			desc: "should return error when create fails",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodPost,
+					fmt.Sprintf("%s/sites/%s/assets", wf.Opt.BaseURL, siteID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					createRequest,
+					&model.AssetUpload{},
+				).Return(common.FromGoErr(fmt.Errorf("some error"))).Once()
+			},
+			expectedRes:     nil,
+			expectedErrCode: http.StatusTeapot,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.mockClosure()
+			uploadURL = tc.uploadURL
+			received = upload{}
+
+			resp, err := wf.Asset.Upload(siteID, "logo.png", strings.NewReader("The quick brown fox jumps over the lazy dog"))
+
+			assert.Equal(t, tc.expectedRes, resp)
+			if tc.expectedErrCode == 0 {
+				assert.Nil(t, err)
+				assert.Equal(t, "logo.png", received.fileName)
+				assert.Equal(t, "The quick brown fox jumps over the lazy dog", received.content)
+				assert.Equal(t, "public-read", received.fields["acl"])
+				assert.Equal(t, "201", received.fields["success_action_status"])
+			} else {
+				assert.Equal(t, tc.expectedErrCode, err.Code)
+			}
+		})
+	}
+}
+
+func TestGetList(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		resultString := `{
+			"assets": [
+				{
+					"id": "63e5889e7fe4eafa7384cea4",
+					"contentType": "image/png",
+					"size": 43,
+					"hostedUrl": "https://uploads-ssl.webflow.com/580e63e98c9a982ac9b8b741/63e5889e7fe4eafa7384cea4_logo.png",
+					"originalFileName": "logo.png",
+					"fileHash": "9e107d9d372bb6826bd81d3542a419d6"
+				}
+			]
+		}`
+
+		_ = json.Unmarshal([]byte(resultString), &result)
+
+		return nil
+	}
+
+	testcases := []struct {
+		desc        string
+		mockClosure func()
+		expectedRes []model.Asset
+		expectedErr *common.Error
+	}{
+		{
+			desc: "should get list assets",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/sites/%s/assets", wf.Opt.BaseURL, siteID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&model.AssetList{},
+				).Return(nil).Once()
+			},
+			expectedRes: []model.Asset{
+				{
+					ID:               "63e5889e7fe4eafa7384cea4",
+					ContentType:      "image/png",
+					Size:             43,
+					HostedURL:        "https://uploads-ssl.webflow.com/580e63e98c9a982ac9b8b741/63e5889e7fe4eafa7384cea4_logo.png",
+					OriginalFileName: "logo.png",
+					FileHash:         "9e107d9d372bb6826bd81d3542a419d6",
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "should return error",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/sites/%s/assets", wf.Opt.BaseURL, siteID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&model.AssetList{},
+				).Return(common.FromGoErr(fmt.Errorf("some error"))).Once()
+			},
+			expectedRes: nil,
+			expectedErr: common.FromGoErr(fmt.Errorf("some error")),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.mockClosure()
+
+			resp, err := wf.Asset.GetList(siteID)
+
+			assert.Equal(t, tc.expectedRes, resp)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestUploadOnce(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	var received upload
+	server := newUploadServer(http.StatusCreated, &received)
+	defer server.Close()
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		resultString := fmt.Sprintf(`{
+			"id": "63e5889e7fe4eafa7384cea5",
+			"hostedUrl": "https://uploads-ssl.webflow.com/580e63e98c9a982ac9b8b741/63e5889e7fe4eafa7384cea5_banner.png",
+			"uploadUrl": %q
+		}`, server.URL)
+
+		_ = json.Unmarshal([]byte(resultString), &result)
+
+		return nil
+	}
+	httpClientMockObj.On(
+		"Call",
+		context.Background(),
+		http.MethodPost,
+		fmt.Sprintf("%s/sites/%s/assets", wf.Opt.BaseURL, siteID),
+		wf.Opt.ApiKey,
+		http.Header(nil),
+		model.CreateAssetRequest{FileName: "banner.png", FileHash: asset.Hash([]byte("banner"))},
+		&model.AssetUpload{},
+	).Return(nil).Once()
+
+	index := asset.NewMemoryIndex()
+	existing := model.Asset{ID: "63e5889e7fe4eafa7384cea4", HostedURL: "https://uploads-ssl.webflow.com/logo.png"}
+	_ = index.Add(asset.Hash([]byte("logo")), existing)
+
+	resp, uploaded, err := wf.Asset.UploadOnce(siteID, "logo.png", strings.NewReader("logo"), index)
+	assert.Nil(t, err)
+	assert.False(t, uploaded)
+	assert.Equal(t, &existing, resp)
+
+	resp, uploaded, err = wf.Asset.UploadOnce(siteID, "banner.png", strings.NewReader("banner"), index)
+	assert.Nil(t, err)
+	assert.True(t, uploaded)
+	assert.Equal(t, "63e5889e7fe4eafa7384cea5", resp.ID)
+	assert.Equal(t, "banner", received.content)
+
+	resp, uploaded, err = wf.Asset.UploadOnce(siteID, "banner-copy.png", strings.NewReader("banner"), index)
+	assert.Nil(t, err)
+	assert.False(t, uploaded)
+	assert.Equal(t, "63e5889e7fe4eafa7384cea5", resp.ID)
+
+	httpClientMockObj.AssertExpectations(t)
+}
+
+func TestFileIndex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "assets.json")
+
+	index, err := asset.NewFileIndex(path)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, index.Len())
+
+	existing := model.Asset{ID: "63e5889e7fe4eafa7384cea4", HostedURL: "https://uploads-ssl.webflow.com/logo.png"}
+	assert.Nil(t, index.Add(asset.Hash([]byte("logo")), existing))
+
+	index, err = asset.NewFileIndex(path)
+	assert.Nil(t, err)
+
+	resp, ok := index.Lookup(asset.Hash([]byte("logo")))
+	assert.True(t, ok)
+	assert.Equal(t, &existing, resp)
+
+	_, ok = index.Lookup(asset.Hash([]byte("banner")))
+	assert.False(t, ok)
+}
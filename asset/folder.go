@@ -0,0 +1,90 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+)
+
+func (a *AssetImpl) GetFolderList(siteID string) ([]model.AssetFolder, *common.Error) {
+	return a.GetFolderListWithContext(context.Background(), siteID)
+}
+
+func (a *AssetImpl) GetFolderListWithContext(ctx context.Context, siteID string) ([]model.AssetFolder, *common.Error) {
+	var response model.AssetFolderList
+	var header http.Header
+
+	err := a.Client.Call(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/sites/%s/asset_folders", a.Opt.BaseURL, siteID),
+		a.Opt.ApiKey,
+		header,
+		nil,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.AssetFolders, nil
+}
+
+func (a *AssetImpl) GetFolder(folderID string) (*model.AssetFolder, *common.Error) {
+	return a.GetFolderWithContext(context.Background(), folderID)
+}
+
+func (a *AssetImpl) GetFolderWithContext(ctx context.Context, folderID string) (*model.AssetFolder, *common.Error) {
+	var response model.AssetFolder
+	var header http.Header
+
+	err := a.Client.Call(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/asset_folders/%s", a.Opt.BaseURL, folderID),
+		a.Opt.ApiKey,
+		header,
+		nil,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (a *AssetImpl) CreateFolder(siteID string, req model.CreateAssetFolderRequest) (*model.AssetFolder, *common.Error) {
+	return a.CreateFolderWithContext(context.Background(), siteID, req)
+}
+
+func (a *AssetImpl) CreateFolderWithContext(ctx context.Context, siteID string, req model.CreateAssetFolderRequest) (*model.AssetFolder, *common.Error) {
+	var response model.AssetFolder
+	var header http.Header
+
+	err := a.Client.Call(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("%s/sites/%s/asset_folders", a.Opt.BaseURL, siteID),
+		a.Opt.ApiKey,
+		header,
+		req,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (a *AssetImpl) Move(assetID string, folderID string) (*model.Asset, *common.Error) {
+	return a.MoveWithContext(context.Background(), assetID, folderID)
+}
+
+func (a *AssetImpl) MoveWithContext(ctx context.Context, assetID string, folderID string) (*model.Asset, *common.Error) {
+	return a.UpdateWithContext(ctx, assetID, model.UpdateAssetRequest{ParentFolder: &folderID})
+}
@@ -0,0 +1,244 @@
+package asset_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nasrul21/go-webflow"
+	"github.com/nasrul21/go-webflow/client/mock"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	assetID  = "63e5889e7fe4eafa7384cea4"
+	folderID = "6390c49774a71f0e3c1a08ee"
+)
+
+func TestUpdateAsset(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		resultString := `{
+			"id": "63e5889e7fe4eafa7384cea4",
+			"displayName": "logo.png",
+			"altText": "Company logo",
+			"parentFolder": "6390c49774a71f0e3c1a08ee"
+		}`
+
+		_ = json.Unmarshal([]byte(resultString), &result)
+
+		return nil
+	}
+
+	altText := "Company logo"
+	folder := folderID
+	expectedRes := &model.Asset{
+		ID:           assetID,
+		DisplayName:  "logo.png",
+		AltText:      "Company logo",
+		ParentFolder: folderID,
+	}
+
+	testcases := []struct {
+		desc        string
+		mockClosure func()
+		call        func() (*model.Asset, *common.Error)
+		expectedRes *model.Asset
+		expectedErr *common.Error
+	}{
+		{
+			desc: "should update alt text",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodPatch,
+					fmt.Sprintf("%s/assets/%s", wf.Opt.BaseURL, assetID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					model.UpdateAssetRequest{AltText: &altText},
+					&model.Asset{},
+				).Return(nil).Once()
+			},
+			call: func() (*model.Asset, *common.Error) {
+				return wf.Asset.UpdateAltText(assetID, "Company logo")
+			},
+			expectedRes: expectedRes,
+			expectedErr: nil,
+		},
+		{
+			desc: "should move asset to folder",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodPatch,
+					fmt.Sprintf("%s/assets/%s", wf.Opt.BaseURL, assetID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					model.UpdateAssetRequest{ParentFolder: &folder},
+					&model.Asset{},
+				).Return(nil).Once()
+			},
+			call: func() (*model.Asset, *common.Error) {
+				return wf.Asset.Move(assetID, folderID)
+			},
+			expectedRes: expectedRes,
+			expectedErr: nil,
+		},
+		{
+			desc: "should return error",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/assets/%s", wf.Opt.BaseURL, assetID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&model.Asset{},
+				).Return(common.FromGoErr(fmt.Errorf("some error"))).Once()
+			},
+			call: func() (*model.Asset, *common.Error) {
+				return wf.Asset.Get(assetID)
+			},
+			expectedRes: nil,
+			expectedErr: common.FromGoErr(fmt.Errorf("some error")),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.mockClosure()
+
+			resp, err := tc.call()
+
+			assert.Equal(t, tc.expectedRes, resp)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGetFolderList(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		resultString := `{
+			"assetFolders": [
+				{
+					"id": "6390c49774a71f0e3c1a08ee",
+					"displayName": "Logos",
+					"assets": ["63e5889e7fe4eafa7384cea4"],
+					"siteId": "580e63e98c9a982ac9b8b741",
+					"createdOn": "2022-12-07T16:51:37.571Z",
+					"lastUpdated": "2022-12-07T16:51:37.571Z"
+				}
+			]
+		}`
+
+		_ = json.Unmarshal([]byte(resultString), &result)
+
+		return nil
+	}
+
+	testcases := []struct {
+		desc        string
+		mockClosure func()
+		expectedRes []model.AssetFolder
+		expectedErr *common.Error
+	}{
+		{
+			desc: "should get list asset folders",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/sites/%s/asset_folders", wf.Opt.BaseURL, siteID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&model.AssetFolderList{},
+				).Return(nil).Once()
+			},
+			expectedRes: []model.AssetFolder{
+				{
+					ID:          folderID,
+					DisplayName: "Logos",
+					Assets:      []string{assetID},
+					SiteID:      siteID,
+					CreatedOn:   time.Date(2022, 12, 7, 16, 51, 37, int(571*time.Millisecond), time.UTC),
+					LastUpdated: time.Date(2022, 12, 7, 16, 51, 37, int(571*time.Millisecond), time.UTC),
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "should return error",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/sites/%s/asset_folders", wf.Opt.BaseURL, siteID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&model.AssetFolderList{},
+				).Return(common.FromGoErr(fmt.Errorf("some error"))).Once()
+			},
+			expectedRes: nil,
+			expectedErr: common.FromGoErr(fmt.Errorf("some error")),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.mockClosure()
+
+			resp, err := wf.Asset.GetFolderList(siteID)
+
+			assert.Equal(t, tc.expectedRes, resp)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestCreateFolder(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		resultString := `{"id": "6390c49774a71f0e3c1a08ee", "displayName": "Logos", "siteId": "580e63e98c9a982ac9b8b741"}`
+
+		_ = json.Unmarshal([]byte(resultString), &result)
+
+		return nil
+	}
+
+	req := model.CreateAssetFolderRequest{DisplayName: "Logos"}
+	httpClientMockObj.On(
+		"Call",
+		context.Background(),
+		http.MethodPost,
+		fmt.Sprintf("%s/sites/%s/asset_folders", wf.Opt.BaseURL, siteID),
+		wf.Opt.ApiKey,
+		http.Header(nil),
+		req,
+		&model.AssetFolder{},
+	).Return(nil).Once()
+
+	resp, err := wf.Asset.CreateFolder(siteID, req)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &model.AssetFolder{ID: folderID, DisplayName: "Logos", SiteID: siteID}, resp)
+}
@@ -0,0 +1,125 @@
+package asset
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+)
+
+// Hash returns the hex encoded MD5 hash Webflow uses to identify asset
+// content.
+func Hash(data []byte) string {
+	hash := md5.Sum(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// Index maps content hashes to assets that were already uploaded.
+type Index interface {
+	Lookup(hash string) (*model.Asset, bool)
+	Add(hash string, asset model.Asset) error
+}
+
+type MemoryIndex struct {
+	mu     sync.RWMutex
+	assets map[string]model.Asset
+}
+
+func NewMemoryIndex() *MemoryIndex {
+	return &MemoryIndex{
+		assets: map[string]model.Asset{},
+	}
+}
+
+// NewSiteIndex builds an index from the assets already stored in a site.
+// Assets listed without a file hash are skipped.
+func NewSiteIndex(ctx context.Context, asset Asset, siteID string) (*MemoryIndex, *common.Error) {
+	assets, err := asset.GetListWithContext(ctx, siteID)
+	if err != nil {
+		return nil, err
+	}
+
+	index := NewMemoryIndex()
+	for _, a := range assets {
+		if a.FileHash != "" {
+			index.assets[a.FileHash] = a
+		}
+	}
+
+	return index, nil
+}
+
+func (m *MemoryIndex) Lookup(hash string) (*model.Asset, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	asset, ok := m.assets[hash]
+	if !ok {
+		return nil, false
+	}
+
+	return &asset, true
+}
+
+func (m *MemoryIndex) Add(hash string, asset model.Asset) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.assets[hash] = asset
+	return nil
+}
+
+func (m *MemoryIndex) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.assets)
+}
+
+// FileIndex is a MemoryIndex persisted as a JSON object of hash to asset, so
+// repeated imports on the same machine can reuse earlier uploads.
+type FileIndex struct {
+	*MemoryIndex
+	path string
+}
+
+func NewFileIndex(path string) (*FileIndex, error) {
+	index := &FileIndex{
+		MemoryIndex: NewMemoryIndex(),
+		path:        path,
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return index, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &index.assets); err != nil {
+		return nil, err
+	}
+
+	return index, nil
+}
+
+func (f *FileIndex) Add(hash string, asset model.Asset) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.assets[hash] = asset
+
+	data, err := json.MarshalIndent(f.assets, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(f.path, data, 0o644)
+}
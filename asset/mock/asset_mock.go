@@ -0,0 +1,202 @@
+package mock
+
+import (
+	"context"
+	"io"
+
+	"github.com/nasrul21/go-webflow/asset"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// AssetMock is a testify mock of asset.Asset.
+// Configuration methods such as With... return the mock itself and are
+// not recorded.
+type AssetMock struct {
+	mock.Mock
+}
+
+var _ asset.Asset = (*AssetMock)(nil)
+
+func (m *AssetMock) GetList(siteID string) ([]model.Asset, *common.Error) {
+	args := m.Called(siteID)
+	res, _ := args.Get(0).([]model.Asset)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *AssetMock) GetListWithContext(ctx context.Context, siteID string) ([]model.Asset, *common.Error) {
+	args := m.Called(ctx, siteID)
+	res, _ := args.Get(0).([]model.Asset)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *AssetMock) Get(assetID string) (*model.Asset, *common.Error) {
+	args := m.Called(assetID)
+	res, _ := args.Get(0).(*model.Asset)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *AssetMock) GetWithContext(ctx context.Context, assetID string) (*model.Asset, *common.Error) {
+	args := m.Called(ctx, assetID)
+	res, _ := args.Get(0).(*model.Asset)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *AssetMock) Update(assetID string, req model.UpdateAssetRequest) (*model.Asset, *common.Error) {
+	args := m.Called(assetID, req)
+	res, _ := args.Get(0).(*model.Asset)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *AssetMock) UpdateWithContext(ctx context.Context, assetID string, req model.UpdateAssetRequest) (*model.Asset, *common.Error) {
+	args := m.Called(ctx, assetID, req)
+	res, _ := args.Get(0).(*model.Asset)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *AssetMock) UpdateAltText(assetID string, altText string) (*model.Asset, *common.Error) {
+	args := m.Called(assetID, altText)
+	res, _ := args.Get(0).(*model.Asset)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *AssetMock) UpdateAltTextWithContext(ctx context.Context, assetID string, altText string) (*model.Asset, *common.Error) {
+	args := m.Called(ctx, assetID, altText)
+	res, _ := args.Get(0).(*model.Asset)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *AssetMock) Move(assetID string, folderID string) (*model.Asset, *common.Error) {
+	args := m.Called(assetID, folderID)
+	res, _ := args.Get(0).(*model.Asset)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *AssetMock) MoveWithContext(ctx context.Context, assetID string, folderID string) (*model.Asset, *common.Error) {
+	args := m.Called(ctx, assetID, folderID)
+	res, _ := args.Get(0).(*model.Asset)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *AssetMock) GetFolderList(siteID string) ([]model.AssetFolder, *common.Error) {
+	args := m.Called(siteID)
+	res, _ := args.Get(0).([]model.AssetFolder)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *AssetMock) GetFolderListWithContext(ctx context.Context, siteID string) ([]model.AssetFolder, *common.Error) {
+	args := m.Called(ctx, siteID)
+	res, _ := args.Get(0).([]model.AssetFolder)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *AssetMock) GetFolder(folderID string) (*model.AssetFolder, *common.Error) {
+	args := m.Called(folderID)
+	res, _ := args.Get(0).(*model.AssetFolder)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *AssetMock) GetFolderWithContext(ctx context.Context, folderID string) (*model.AssetFolder, *common.Error) {
+	args := m.Called(ctx, folderID)
+	res, _ := args.Get(0).(*model.AssetFolder)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *AssetMock) CreateFolder(siteID string, req model.CreateAssetFolderRequest) (*model.AssetFolder, *common.Error) {
+	args := m.Called(siteID, req)
+	res, _ := args.Get(0).(*model.AssetFolder)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *AssetMock) CreateFolderWithContext(ctx context.Context, siteID string, req model.CreateAssetFolderRequest) (*model.AssetFolder, *common.Error) {
+	args := m.Called(ctx, siteID, req)
+	res, _ := args.Get(0).(*model.AssetFolder)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *AssetMock) Create(siteID string, req model.CreateAssetRequest) (*model.AssetUpload, *common.Error) {
+	args := m.Called(siteID, req)
+	res, _ := args.Get(0).(*model.AssetUpload)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *AssetMock) CreateWithContext(ctx context.Context, siteID string, req model.CreateAssetRequest) (*model.AssetUpload, *common.Error) {
+	args := m.Called(ctx, siteID, req)
+	res, _ := args.Get(0).(*model.AssetUpload)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *AssetMock) Upload(siteID string, fileName string, content io.Reader) (*model.Asset, *common.Error) {
+	args := m.Called(siteID, fileName, content)
+	res, _ := args.Get(0).(*model.Asset)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *AssetMock) UploadWithContext(ctx context.Context, siteID string, fileName string, content io.Reader) (*model.Asset, *common.Error) {
+	args := m.Called(ctx, siteID, fileName, content)
+	res, _ := args.Get(0).(*model.Asset)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *AssetMock) UploadOnce(siteID string, fileName string, content io.Reader, index asset.Index) (*model.Asset, bool, *common.Error) {
+	args := m.Called(siteID, fileName, content, index)
+	res, _ := args.Get(0).(*model.Asset)
+	found, _ := args.Get(1).(bool)
+	err, _ := args.Get(2).(*common.Error)
+
+	return res, found, err
+}
+
+func (m *AssetMock) UploadOnceWithContext(ctx context.Context, siteID string, fileName string, content io.Reader, index asset.Index) (*model.Asset, bool, *common.Error) {
+	args := m.Called(ctx, siteID, fileName, content, index)
+	res, _ := args.Get(0).(*model.Asset)
+	found, _ := args.Get(1).(bool)
+	err, _ := args.Get(2).(*common.Error)
+
+	return res, found, err
+}
+
+func (m *AssetMock) WithOptimizer(optimizer *asset.Optimizer) asset.Asset {
+	return m
+}
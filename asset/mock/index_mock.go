@@ -0,0 +1,27 @@
+package mock
+
+import (
+	"github.com/nasrul21/go-webflow/asset"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// IndexMock is a testify mock of asset.Index.
+type IndexMock struct {
+	mock.Mock
+}
+
+var _ asset.Index = (*IndexMock)(nil)
+
+func (m *IndexMock) Lookup(hash string) (*model.Asset, bool) {
+	args := m.Called(hash)
+	res, _ := args.Get(0).(*model.Asset)
+
+	return res, args.Bool(1)
+}
+
+func (m *IndexMock) Add(hash string, asset model.Asset) error {
+	args := m.Called(hash, asset)
+
+	return args.Error(0)
+}
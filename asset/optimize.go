@@ -0,0 +1,270 @@
+package asset
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"path/filepath"
+	"strings"
+
+	// Register the PNG decoder for the formats the optimizer accepts.
+	_ "image/png"
+)
+
+// Optimizer downsizes and re-encodes images as JPEG before they are
+// uploaded. Re-encoding drops any EXIF or other metadata the original file
+// carried, so JPEGs are first rotated upright according to their EXIF
+// orientation. Images with transparent pixels and animated GIFs are left
+// untouched since JPEG cannot represent them, as is content that is not a
+// decodable image. The original is also kept, metadata included, when the
+// JPEG is not smaller.
+type Optimizer struct {
+	// MaxWidth and MaxHeight bound the output dimensions, keeping the aspect
+	// ratio. Zero means no limit.
+	MaxWidth  int
+	MaxHeight int
+	// Quality is the JPEG quality from 1 to 100, jpeg.DefaultQuality when zero.
+	Quality int
+	// OnOptimized, when set, is called with the result of every image the
+	// optimizer processed.
+	OnOptimized func(result OptimizeResult)
+}
+
+type OptimizeResult struct {
+	FileName       string
+	OriginalSize   int
+	OptimizedSize  int
+	OriginalWidth  int
+	OriginalHeight int
+	Width          int
+	Height         int
+}
+
+// Saved returns the number of bytes saved.
+func (r OptimizeResult) Saved() int {
+	return r.OriginalSize - r.OptimizedSize
+}
+
+// Optimize returns the optimized content and file name. The file name gets a
+// .jpg extension when the content was re-encoded.
+func (o *Optimizer) Optimize(fileName string, data []byte) (string, []byte, error) {
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil || !opaque(src) || (format == "gif" && animated(data)) {
+		return fileName, data, nil
+	}
+	if format == "jpeg" {
+		src = orient(src, orientation(data))
+	}
+
+	bounds := src.Bounds()
+	width, height := fit(bounds.Dx(), bounds.Dy(), o.MaxWidth, o.MaxHeight)
+
+	quality := o.Quality
+	if quality == 0 {
+		quality = jpeg.DefaultQuality
+	}
+
+	out := &bytes.Buffer{}
+	if err := jpeg.Encode(out, resize(src, width, height), &jpeg.Options{Quality: quality}); err != nil {
+		return "", nil, err
+	}
+	if out.Len() >= len(data) {
+		return fileName, data, nil
+	}
+
+	optimizedName := strings.TrimSuffix(fileName, filepath.Ext(fileName)) + ".jpg"
+	if o.OnOptimized != nil {
+		o.OnOptimized(OptimizeResult{
+			FileName:       optimizedName,
+			OriginalSize:   len(data),
+			OptimizedSize:  out.Len(),
+			OriginalWidth:  bounds.Dx(),
+			OriginalHeight: bounds.Dy(),
+			Width:          width,
+			Height:         height,
+		})
+	}
+
+	return optimizedName, out.Bytes(), nil
+}
+
+func opaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+
+	return true
+}
+
+func animated(data []byte) bool {
+	g, err := gif.DecodeAll(bytes.NewReader(data))
+	return err == nil && len(g.Image) > 1
+}
+
+// orientation returns the EXIF orientation of JPEG data, 1 when it has none.
+func orientation(data []byte) int {
+	for i := 2; i+4 <= len(data) && data[i] == 0xff; {
+		marker := data[i+1]
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if marker == 0xda || length < 2 || i+2+length > len(data) {
+			break
+		}
+
+		segment := data[i+4 : i+2+length]
+		if marker == 0xe1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+
+	return 1
+}
+
+// exifOrientation reads the orientation tag from the first IFD of a TIFF
+// structure.
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 0 || offset+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[offset:]))
+	for e := 0; e < entries; e++ {
+		entry := offset + 2 + e*12
+		if entry+12 > len(tiff) {
+			break
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			value := int(order.Uint16(tiff[entry+8:]))
+			if value < 1 || value > 8 {
+				return 1
+			}
+			return value
+		}
+	}
+
+	return 1
+}
+
+// orient turns src upright for the given EXIF orientation.
+func orient(src image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return src
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			dx, dy := x, y
+			switch orientation {
+			case 2:
+				dx = width - 1 - x
+			case 3:
+				dx, dy = width-1-x, height-1-y
+			case 4:
+				dy = height - 1 - y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = height-1-y, x
+			case 7:
+				dx, dy = height-1-y, width-1-x
+			case 8:
+				dx, dy = y, width-1-x
+			}
+			dst.Set(dx, dy, src.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+
+	return dst
+}
+
+func fit(width, height, maxWidth, maxHeight int) (int, int) {
+	if maxWidth > 0 && width > maxWidth {
+		height = height * maxWidth / width
+		width = maxWidth
+	}
+	if maxHeight > 0 && height > maxHeight {
+		width = width * maxHeight / height
+		height = maxHeight
+	}
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	return width, height
+}
+
+// resize scales src down to width x height by averaging the source pixels
+// covered by each destination pixel.
+func resize(src image.Image, width, height int) image.Image {
+	bounds := src.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+
+	if width == bounds.Dx() && height == bounds.Dy() {
+		return rgba
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	for y := 0; y < height; y++ {
+		y0 := y * srcHeight / height
+		y1 := (y + 1) * srcHeight / height
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+
+		for x := 0; x < width; x++ {
+			x0 := x * srcWidth / width
+			x1 := (x + 1) * srcWidth / width
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				offset := rgba.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += uint32(rgba.Pix[offset])
+					g += uint32(rgba.Pix[offset+1])
+					b += uint32(rgba.Pix[offset+2])
+					a += uint32(rgba.Pix[offset+3])
+					offset += 4
+					n++
+				}
+			}
+
+			dst.SetRGBA(x, y, color.RGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: uint8(a / n)})
+		}
+	}
+
+	return dst
+}
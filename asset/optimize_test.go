@@ -0,0 +1,267 @@
+package asset_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"testing"
+
+	"github.com/nasrul21/go-webflow"
+	"github.com/nasrul21/go-webflow/asset"
+	"github.com/nasrul21/go-webflow/client/mock"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
+)
+
+func encodeImage(t *testing.T, width, height int, alpha uint8, encode func(*bytes.Buffer, image.Image) error) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x * 7), G: uint8(y * 13), B: uint8(x * y), A: alpha})
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	if err := encode(buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func encodeJPEG(buf *bytes.Buffer, img image.Image) error {
+	return jpeg.Encode(buf, img, &jpeg.Options{Quality: 100})
+}
+
+func encodePNG(buf *bytes.Buffer, img image.Image) error {
+	return png.Encode(buf, img)
+}
+
+func encodeAnimatedGIF(t *testing.T) []byte {
+	animation := &gif.GIF{}
+	for i := 0; i < 2; i++ {
+		frame := image.NewPaletted(image.Rect(0, 0, 400, 300), palette.Plan9)
+		for y := 0; y < 300; y++ {
+			for x := 0; x < 400; x++ {
+				frame.SetColorIndex(x, y, uint8(x*7+y*13+i))
+			}
+		}
+		animation.Image = append(animation.Image, frame)
+		animation.Delay = append(animation.Delay, 10)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := gif.EncodeAll(buf, animation); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestOptimize(t *testing.T) {
+	photo := encodeImage(t, 400, 300, 0xff, encodeJPEG)
+	screenshot := encodeImage(t, 120, 240, 0xff, encodePNG)
+	logo := encodeImage(t, 400, 300, 0x80, encodePNG)
+	thumbnail := encodeImage(t, 100, 100, 0xff, func(buf *bytes.Buffer, img image.Image) error {
+		return jpeg.Encode(buf, img, &jpeg.Options{Quality: 10})
+	})
+
+	testcases := []struct {
+		desc           string
+		fileName       string
+		data           []byte
+		expectedName   string
+		expectedWidth  int
+		expectedHeight int
+		expectedResult bool
+	}{
+		{
+			desc:           "should downsize jpeg to max width",
+			fileName:       "photo.jpeg",
+			data:           photo,
+			expectedName:   "photo.jpg",
+			expectedWidth:  200,
+			expectedHeight: 150,
+			expectedResult: true,
+		},
+		{
+			desc:           "should downsize png to max height and encode jpeg",
+			fileName:       "screenshot.png",
+			data:           screenshot,
+			expectedName:   "screenshot.jpg",
+			expectedWidth:  80,
+			expectedHeight: 160,
+			expectedResult: true,
+		},
+		{
+			desc:         "should keep transparent image",
+			fileName:     "logo.png",
+			data:         logo,
+			expectedName: "logo.png",
+		},
+		{
+			desc:         "should keep animated gif",
+			fileName:     "banner.gif",
+			data:         encodeAnimatedGIF(t),
+			expectedName: "banner.gif",
+		},
+		{
+			desc:         "should keep original when output is not smaller",
+			fileName:     "thumbnail.jpeg",
+			data:         thumbnail,
+			expectedName: "thumbnail.jpeg",
+		},
+		{
+			desc:         "should keep content that is not an image",
+			fileName:     "notes.txt",
+			data:         []byte("not an image"),
+			expectedName: "notes.txt",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var results []asset.OptimizeResult
+			optimizer := &asset.Optimizer{
+				MaxWidth:  200,
+				MaxHeight: 160,
+				Quality:   80,
+				OnOptimized: func(result asset.OptimizeResult) {
+					results = append(results, result)
+				},
+			}
+
+			name, data, err := optimizer.Optimize(tc.fileName, tc.data)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedName, name)
+			if !tc.expectedResult {
+				assert.Equal(t, tc.data, data)
+				assert.Len(t, results, 0)
+				return
+			}
+
+			img, format, err := image.Decode(bytes.NewReader(data))
+			assert.Nil(t, err)
+			assert.Equal(t, "jpeg", format)
+			assert.Equal(t, tc.expectedWidth, img.Bounds().Dx())
+			assert.Equal(t, tc.expectedHeight, img.Bounds().Dy())
+
+			assert.Len(t, results, 1)
+			assert.Equal(t, len(tc.data), results[0].OriginalSize)
+			assert.Equal(t, len(data), results[0].OptimizedSize)
+			assert.Equal(t, tc.expectedWidth, results[0].Width)
+			assert.True(t, results[0].Saved() > 0)
+		})
+	}
+}
+
+// withOrientation inserts an EXIF segment carrying orientation after the
+// start of image marker of a JPEG.
+func withOrientation(data []byte, orientation uint16) []byte {
+	exif := []byte("Exif\x00\x00MM\x00\x2a\x00\x00\x00\x08\x00\x01\x01\x12\x00\x03\x00\x00\x00\x01")
+	exif = append(exif, byte(orientation>>8), byte(orientation), 0, 0, 0, 0, 0, 0)
+
+	segment := []byte{0xff, 0xe1, byte((len(exif) + 2) >> 8), byte(len(exif) + 2)}
+	segment = append(segment, exif...)
+
+	return append(append(append([]byte{}, data[:2]...), segment...), data[2:]...)
+}
+
+func TestOptimizeOrientation(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 400, 300))
+	for y := 0; y < 300; y++ {
+		for x := 0; x < 400; x++ {
+			if x < 200 {
+				img.Set(x, y, color.RGBA{R: 0xff, A: 0xff})
+			} else {
+				img.Set(x, y, color.RGBA{B: 0xff, A: 0xff})
+			}
+		}
+	}
+	buf := &bytes.Buffer{}
+	if err := encodeJPEG(buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		desc        string
+		orientation uint16
+		topIsRed    bool
+	}{
+		{
+			desc:        "should rotate clockwise for orientation 6",
+			orientation: 6,
+			topIsRed:    true,
+		},
+		{
+			desc:        "should rotate counter clockwise for orientation 8",
+			orientation: 8,
+			topIsRed:    false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			optimizer := &asset.Optimizer{MaxWidth: 200, MaxHeight: 160, Quality: 80}
+
+			_, data, err := optimizer.Optimize("photo.jpeg", withOrientation(buf.Bytes(), tc.orientation))
+			assert.Nil(t, err)
+
+			out, _, err := image.Decode(bytes.NewReader(data))
+			assert.Nil(t, err)
+			assert.Equal(t, 120, out.Bounds().Dx())
+			assert.Equal(t, 160, out.Bounds().Dy())
+
+			r, _, b, _ := out.At(60, 10).RGBA()
+			assert.Equal(t, tc.topIsRed, r > b)
+			r, _, b, _ = out.At(60, 150).RGBA()
+			assert.Equal(t, !tc.topIsRed, r > b)
+		})
+	}
+}
+
+func TestUploadWithOptimizer(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	var received upload
+	server := newUploadServer(http.StatusCreated, &received)
+	defer server.Close()
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		_ = json.Unmarshal([]byte(fmt.Sprintf(`{"id": "63e5889e7fe4eafa7384cea4", "uploadUrl": %q}`, server.URL)), &result)
+		return nil
+	}
+	httpClientMockObj.On(
+		"Call",
+		context.Background(),
+		http.MethodPost,
+		fmt.Sprintf("%s/sites/%s/assets", wf.Opt.BaseURL, siteID),
+		wf.Opt.ApiKey,
+		http.Header(nil),
+		testifymock.MatchedBy(func(req model.CreateAssetRequest) bool {
+			return req.FileName == "photo.jpg"
+		}),
+		&model.AssetUpload{},
+	).Return(nil).Once()
+
+	photo := encodeImage(t, 400, 300, 0xff, encodeJPEG)
+	resp, err := wf.Asset.WithOptimizer(&asset.Optimizer{MaxWidth: 100}).Upload(siteID, "photo.jpeg", bytes.NewReader(photo))
+
+	assert.Nil(t, err)
+	assert.Equal(t, asset.Hash([]byte(received.content)), resp.FileHash)
+	assert.Equal(t, "photo.jpg", received.fileName)
+	assert.True(t, len(received.content) < len(photo))
+	httpClientMockObj.AssertExpectations(t)
+}
@@ -0,0 +1,107 @@
+package cassette_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nasrul21/go-webflow"
+	"github.com/nasrul21/go-webflow/cassette"
+	"github.com/nasrul21/go-webflow/client"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/nasrul21/go-webflow/webflowtest"
+	"github.com/stretchr/testify/assert"
+)
+
+const siteID = "580e63e98c9a982ac9b8b741"
+
+func newWebflow(baseURL string, httpClient *http.Client) *webflow.Webflow {
+	wf := webflow.New(webflowtest.DefaultToken).WithHttpClient(&client.ClientImpl{HttpClient: httpClient})
+	wf.Opt.BaseURL = baseURL
+
+	return wf
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "orders.json")
+
+	srv := webflowtest.NewServer(&webflowtest.Fixtures{
+		Sites: []model.Site{{ID: siteID, Name: "Shop"}},
+		Orders: map[string][]model.Order{
+			siteID: {{OrderID: "dd6-5ca", Status: model.OrderStatusUnfulfilled}},
+		},
+	})
+	baseURL := srv.URL
+
+	rec, err := cassette.New(path, cassette.ModeAuto)
+	assert.Nil(t, err)
+	assert.Equal(t, cassette.ModeRecord, rec.Mode)
+	rec.Transport = srv.Client().Transport
+
+	wf := newWebflow(baseURL, rec.HTTPClient())
+	order, werr := wf.Order.Fulfill(siteID, "dd6-5ca", model.FulfillOrderRequest{})
+	assert.Nil(t, werr)
+	assert.Equal(t, model.OrderStatusFulfilled, order.Status)
+
+	_, werr = wf.Order.Get(siteID, "unknown")
+	assert.Equal(t, http.StatusNotFound, werr.Code)
+
+	assert.Nil(t, rec.Save())
+	srv.Close()
+
+	data, _ := ioutil.ReadFile(path)
+	assert.False(t, strings.Contains(string(data), webflowtest.DefaultToken))
+	assert.True(t, strings.Contains(string(data), cassette.Redacted))
+
+	rec, err = cassette.New(path, cassette.ModeAuto)
+	assert.Nil(t, err)
+	assert.Equal(t, cassette.ModeReplay, rec.Mode)
+	assert.Len(t, rec.Interactions(), 3)
+
+	wf = newWebflow(baseURL, rec.HTTPClient())
+	order, werr = wf.Order.Fulfill(siteID, "dd6-5ca", model.FulfillOrderRequest{})
+	assert.Nil(t, werr)
+	assert.Equal(t, model.OrderStatusFulfilled, order.Status)
+
+	_, werr = wf.Order.Get(siteID, "unknown")
+	assert.Equal(t, http.StatusNotFound, werr.Code)
+	assert.Equal(t, "NotFound", werr.Name)
+
+	_, werr = wf.Order.Get(siteID, "unknown")
+	assert.Contains(t, werr.Message, "no recorded interaction")
+}
+
+func TestReplayMissingCassette(t *testing.T) {
+	_, err := cassette.New(filepath.Join(t.TempDir(), "missing.json"), cassette.ModeReplay)
+	assert.NotNil(t, err)
+}
+
+func TestReplayMatchesBody(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "body.json")
+	srv := webflowtest.NewServer(&webflowtest.Fixtures{Sites: []model.Site{{ID: siteID}}})
+	defer srv.Close()
+
+	rec, _ := cassette.New(path, cassette.ModeRecord)
+	rec.Transport = srv.Client().Transport
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/sites/"+siteID+"/webhooks", strings.NewReader(`{"triggerType": "site_publish", "url": "https://example.com/hook"}`))
+	req.Header.Set("Authorization", "Bearer "+webflowtest.DefaultToken)
+	resp, err := rec.HTTPClient().Do(req)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Nil(t, rec.Save())
+
+	rec, _ = cassette.New(path, cassette.ModeReplay)
+
+	req, _ = http.NewRequest(http.MethodPost, srv.URL+"/sites/"+siteID+"/webhooks", strings.NewReader(`{"url":"https://example.com/hook","triggerType":"site_publish"}`))
+	resp, err = rec.HTTPClient().Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	req, _ = http.NewRequest(http.MethodPost, srv.URL+"/sites/"+siteID+"/webhooks", strings.NewReader(`{"triggerType":"form_submission"}`))
+	_, err = rec.HTTPClient().Do(req)
+	assert.True(t, errors.Is(err, cassette.ErrNoInteraction))
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/nasrul21/go-webflow"
+	"github.com/nasrul21/go-webflow/common"
+)
+
+const (
+	exitOK = iota
+	exitError
+	exitUsage
+	exitAuth
+	exitNotFound
+	exitInvalid
+	exitRateLimited
+	exitServer
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// usageError reports a command line or configuration the command cannot run
+// with.
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func usagef(format string, args ...interface{}) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// apiError carries a *common.Error, which is not an error itself, through
+// the commands.
+type apiError struct {
+	err *common.Error
+}
+
+func (e *apiError) Error() string {
+	msg := e.err.Message
+	if msg == "" {
+		msg = e.err.Err
+	}
+	if e.err.Name != "" {
+		msg = fmt.Sprintf("%s (%s)", msg, e.err.Name)
+	}
+
+	return msg
+}
+
+// wrap returns nil for a nil err, which a nil *common.Error converted to
+// error would not be.
+func wrap(err *common.Error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &apiError{err: err}
+}
+
+// config is the content of the config file.
+type config struct {
+	Token   string `json:"token"`
+	BaseURL string `json:"baseUrl"`
+}
+
+// app holds what every command shares: the streams, the global flags and
+// the client built from them.
+type app struct {
+	name   string
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(string) string
+
+	token      string
+	configPath string
+	output     string
+}
+
+// flags returns the flag set of the command with the global flags defined.
+func (a *app) flags() *flag.FlagSet {
+	fs := flag.NewFlagSet("webflow "+a.name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.StringVar(&a.token, "token", "", "API token, instead of WEBFLOW_TOKEN or the config file")
+	fs.StringVar(&a.configPath, "config", "", "config file path")
+	fs.StringVar(&a.output, "output", outputTable, "output format, table or json")
+
+	return fs
+}
+
+// parse parses the command line of the command and checks it has between
+// min and max positional arguments.
+func (a *app) parse(fs *flag.FlagSet, args []string, min int, max int) ([]string, error) {
+	// Flags may follow the positional arguments, which the flag package
+	// stops at. A "--" ends the flags, so every argument after it is
+	// positional even if it starts with a dash.
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, &usageError{msg: err.Error()}
+		}
+		rest := fs.Args()
+		if parsed := len(args) - len(rest); parsed > 0 && args[parsed-1] == "--" {
+			positional = append(positional, rest...)
+			break
+		}
+		if len(rest) == 0 {
+			break
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+	if a.output != outputTable && a.output != outputJSON {
+		return nil, usagef("unknown output format %q", a.output)
+	}
+	if len(positional) < min || len(positional) > max {
+		want := fmt.Sprint(min)
+		if max > min {
+			want = fmt.Sprintf("%d to %d", min, max)
+		}
+		return nil, usagef("%s takes %s arguments, got %d", a.name, want, len(positional))
+	}
+
+	return positional, nil
+}
+
+func (a *app) loadConfig() (config, error) {
+	var cfg config
+
+	path := a.configPath
+	explicit := path != ""
+	if !explicit {
+		path = a.getenv("WEBFLOW_CONFIG")
+		explicit = path != ""
+	}
+	if !explicit {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return cfg, nil
+		}
+		path = filepath.Join(dir, "webflow", "config.json")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+		return cfg, usagef("cannot read config: %v", err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, usagef("cannot read config %s: %v", path, err)
+	}
+
+	return cfg, nil
+}
+
+// client returns the API client for the token of the command line,
+// environment or config file.
+func (a *app) client() (*webflow.Webflow, error) {
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	token := a.token
+	if token == "" {
+		token = a.getenv("WEBFLOW_TOKEN")
+	}
+	if token == "" {
+		token = cfg.Token
+	}
+	if token == "" {
+		return nil, wrap(&common.Error{
+			Code:    http.StatusUnauthorized,
+			Message: "no API token, set WEBFLOW_TOKEN or use -token",
+		})
+	}
+
+	wf := webflow.New(token)
+	if v := a.getenv("WEBFLOW_API_URL"); v != "" {
+		wf.Opt.BaseURL = v
+	} else if cfg.BaseURL != "" {
+		wf.Opt.BaseURL = cfg.BaseURL
+	}
+
+	return wf, nil
+}
+
+// print writes v as JSON, or as a table of the header and rows.
+func (a *app) print(v interface{}, header []string, rows [][]string) error {
+	if a.output == outputJSON {
+		enc := json.NewEncoder(a.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	if header != nil {
+		fmt.Fprintln(w, strings.Join(header, "\t"))
+	}
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+
+	return w.Flush()
+}
+
+// fail reports err and returns the exit status it maps to.
+func (a *app) fail(err error) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+
+	fmt.Fprintf(a.stderr, "webflow: %v\n", err)
+
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		return exitUsage
+	}
+
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		return exitError
+	}
+	if problems, ok := apiErr.err.Problems.([]interface{}); ok {
+		for _, problem := range problems {
+			fmt.Fprintf(a.stderr, "  %v\n", problem)
+		}
+	}
+
+	return exitCode(apiErr.err)
+}
+
+// exitCode maps the status code of an API error to an exit status.
+func exitCode(err *common.Error) int {
+	switch {
+	case err.Code == http.StatusUnauthorized || err.Code == http.StatusForbidden:
+		return exitAuth
+	case err.Code == http.StatusNotFound:
+		return exitNotFound
+	case err.Code == http.StatusBadRequest || err.Code == http.StatusConflict || err.Code == http.StatusUnprocessableEntity:
+		return exitInvalid
+	case err.Code == http.StatusTooManyRequests:
+		return exitRateLimited
+	case err.Code >= 500:
+		return exitServer
+	default:
+		return exitError
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nasrul21/go-webflow"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+)
+
+func infoCommand(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	if _, err := a.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	wf, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	info, apiErr := wf.Meta.GetInfoWithContext(ctx)
+	if apiErr != nil {
+		return wrap(apiErr)
+	}
+
+	sites := make([]string, 0, len(info.Sites))
+	for _, site := range info.Sites {
+		sites = append(sites, fmt.Sprint(site))
+	}
+
+	return a.print(info, nil, [][]string{
+		{"ID", info.ID},
+		{"Grant type", info.GrantType},
+		{"Status", info.Status},
+		{"Rate limit", fmt.Sprintf("%d/min", info.RateLimit)},
+		{"Created", formatTime(info.CreatedOn)},
+		{"Last used", formatTime(info.LastUsed)},
+		{"Application", info.Application.Name},
+		{"Sites", strings.Join(sites, ", ")},
+	})
+}
+
+func userCommand(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	if _, err := a.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	wf, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	user, apiErr := wf.Meta.GetUserWithContext(ctx)
+	if apiErr != nil {
+		return wrap(apiErr)
+	}
+
+	return a.print(user, []string{"ID", "EMAIL", "NAME"}, [][]string{
+		{user.User.ID, user.User.Email, strings.TrimSpace(user.User.FirstName + " " + user.User.LastName)},
+	})
+}
+
+func sitesListCommand(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	if _, err := a.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	wf, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	sites, apiErr := wf.Site.GetListWithContext(ctx)
+	if apiErr != nil {
+		return wrap(apiErr)
+	}
+
+	rows := make([][]string, 0, len(sites))
+	for _, site := range sites {
+		rows = append(rows, []string{site.ID, site.ShortName, site.Name, formatTime(site.LastPublished)})
+	}
+
+	return a.print(sites, []string{"ID", "SHORT NAME", "NAME", "LAST PUBLISHED"}, rows)
+}
+
+func domainsListCommand(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	args, err := a.parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	wf, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	site, err := findSite(ctx, wf, args[0])
+	if err != nil {
+		return err
+	}
+	domains, apiErr := wf.Domain.GetListWithContext(ctx, site.ID)
+	if apiErr != nil {
+		return wrap(apiErr)
+	}
+
+	rows := make([][]string, 0, len(domains))
+	for _, domain := range domains {
+		rows = append(rows, []string{domain.ID, domain.Name})
+	}
+
+	return a.print(domains, []string{"ID", "NAME"}, rows)
+}
+
+// stringsFlag collects the values of a flag given several times.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(v string) error {
+	*f = append(*f, v)
+	return nil
+}
+
+var _ flag.Value = (*stringsFlag)(nil)
+
+func publishCommand(ctx context.Context, a *app, args []string) error {
+	var domains stringsFlag
+	fs := a.flags()
+	fs.Var(&domains, "domain", "domain to publish to, repeatable (default all custom domains and the webflow.io subdomain)")
+	args, err := a.parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	wf, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	site, err := findSite(ctx, wf, args[0])
+	if err != nil {
+		return err
+	}
+	if len(domains) == 0 {
+		custom, apiErr := wf.Domain.GetListWithContext(ctx, site.ID)
+		if apiErr != nil {
+			return wrap(apiErr)
+		}
+		for _, domain := range custom {
+			domains = append(domains, domain.Name)
+		}
+		domains = append(domains, site.ShortName+".webflow.io")
+	}
+
+	res, apiErr := wf.Site.PublishWithContext(ctx, site.ID, model.PublishSiteRequest{Domains: domains})
+	if apiErr != nil {
+		return wrap(apiErr)
+	}
+
+	status := "published"
+	if res.Queued {
+		status = "queued"
+	}
+
+	return a.print(res, nil, [][]string{
+		{"Site", site.ShortName},
+		{"Domains", strings.Join(domains, ", ")},
+		{"Status", status},
+	})
+}
+
+// findSite returns the site with the given ID or short name.
+func findSite(ctx context.Context, wf *webflow.Webflow, site string) (*model.Site, error) {
+	sites, apiErr := wf.Site.GetListWithContext(ctx)
+	if apiErr != nil {
+		return nil, wrap(apiErr)
+	}
+
+	for i := range sites {
+		if sites[i].ID == site || sites[i].ShortName == site {
+			return &sites[i], nil
+		}
+	}
+
+	return nil, wrap(&common.Error{
+		Code:    http.StatusNotFound,
+		Message: fmt.Sprintf("site %q not found", site),
+		Name:    "NotFound",
+	})
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
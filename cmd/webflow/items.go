@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/nasrul21/go-webflow"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/item"
+	"github.com/nasrul21/go-webflow/model"
+)
+
+func itemsExportCommand(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	format := fs.String("format", item.FormatCSV, "export format, csv, json or ndjson")
+	refs := fs.String("refs", item.ReferencesID, "export references as id or slug")
+	file := fs.String("file", "", "file to write to instead of stdout")
+	siteFlag := fs.String("site", "", "site to find the collection in by slug")
+	args, err := a.parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	switch *format {
+	case item.FormatCSV, item.FormatJSON, item.FormatNDJSON:
+	default:
+		return usagef("unknown export format %q", *format)
+	}
+	if *refs != item.ReferencesID && *refs != item.ReferencesSlug {
+		return usagef("unknown references %q", *refs)
+	}
+
+	wf, err := a.client()
+	if err != nil {
+		return err
+	}
+	collection, err := findCollection(ctx, wf, *siteFlag, args[0])
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = a.stdout
+	var f *os.File
+	if *file != "" {
+		f, err = os.Create(*file)
+		if err != nil {
+			return err
+		}
+		w = f
+	}
+
+	count, apiErr := item.Export(ctx, wf.Item, *collection, w, item.ExportOptions{Format: *format, References: *refs})
+	if f != nil {
+		// A partial export is worse than none, so the file is removed on
+		// error.
+		closeErr := f.Close()
+		if apiErr == nil && closeErr != nil {
+			apiErr = common.FromGoErr(closeErr)
+		}
+		if apiErr != nil {
+			os.Remove(*file)
+			return wrap(apiErr)
+		}
+		fmt.Fprintf(a.stderr, "exported %d items of %s to %s\n", count, collection.Slug, *file)
+	}
+
+	return wrap(apiErr)
+}
+
+// findCollection returns the collection with its schema. Without a site the
+// collection must be given by ID, with one it may be given by slug.
+func findCollection(ctx context.Context, wf *webflow.Webflow, siteArg string, collectionArg string) (*model.Collection, error) {
+	collectionID := collectionArg
+	if siteArg != "" {
+		site, err := findSite(ctx, wf, siteArg)
+		if err != nil {
+			return nil, err
+		}
+		collections, apiErr := wf.Collection.GetListWithContext(ctx, site.ID)
+		if apiErr != nil {
+			return nil, wrap(apiErr)
+		}
+
+		collectionID = ""
+		for _, collection := range collections {
+			if collection.ID == collectionArg || collection.Slug == collectionArg {
+				collectionID = collection.ID
+				break
+			}
+		}
+		if collectionID == "" {
+			return nil, wrap(&common.Error{
+				Code:    http.StatusNotFound,
+				Message: fmt.Sprintf("collection %q not found in %s", collectionArg, site.ShortName),
+				Name:    "NotFound",
+			})
+		}
+	}
+
+	collection, apiErr := wf.Collection.GetWithContext(ctx, collectionID)
+	if apiErr != nil {
+		return nil, wrap(apiErr)
+	}
+
+	return collection, nil
+}
+
+// importRow is a row of the import report.
+type importRow struct {
+	Line     int           `json:"line"`
+	Slug     string        `json:"slug"`
+	Status   string        `json:"status"`
+	ItemID   string        `json:"itemId,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	Problems []interface{} `json:"problems,omitempty"`
+}
+
+func itemsImportCommand(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	mappingPath := fs.String("mapping", "", "JSON mapping of CSV columns to fields (default columns named like field slugs)")
+	dryRun := fs.Bool("dry-run", false, "validate and report without writing")
+	live := fs.Bool("live", false, "publish the created and updated items at once")
+	siteFlag := fs.String("site", "", "site to find the collection in by slug")
+	args, err := a.parse(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+
+	var opts item.ImportOptions
+	if *mappingPath != "" {
+		mapping, err := item.LoadMapping(*mappingPath)
+		if err != nil {
+			return &usageError{msg: err.Error()}
+		}
+		opts.Mapping = *mapping
+	}
+	opts.DryRun = *dryRun
+	opts.Live = *live
+
+	var r io.Reader = os.Stdin
+	if args[1] != "-" {
+		f, err := os.Open(args[1])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	wf, err := a.client()
+	if err != nil {
+		return err
+	}
+	collection, err := findCollection(ctx, wf, *siteFlag, args[0])
+	if err != nil {
+		return err
+	}
+
+	results, apiErr := item.Import(ctx, wf.Item, *collection, r, opts)
+	if apiErr != nil {
+		return wrap(apiErr)
+	}
+
+	report := make([]importRow, 0, len(results))
+	rows := make([][]string, 0, len(results))
+	counts := map[string]int{}
+	for _, result := range results {
+		row := importRow{Line: result.Line, Slug: result.Slug, Status: result.Status, ItemID: result.ItemID}
+		if result.Err != nil {
+			row.Error = (&apiError{err: result.Err}).Error()
+			row.Problems, _ = result.Err.Problems.([]interface{})
+		}
+		report = append(report, row)
+		counts[result.Status]++
+
+		detail := row.Error
+		for _, problem := range row.Problems {
+			detail += fmt.Sprintf("; %v", problem)
+		}
+		rows = append(rows, []string{fmt.Sprint(row.Line), row.Slug, row.Status, row.ItemID, detail})
+	}
+
+	if err := a.print(report, []string{"LINE", "SLUG", "STATUS", "ITEM", "ERROR"}, rows); err != nil {
+		return err
+	}
+
+	prefix := ""
+	if opts.DryRun {
+		prefix = "dry run: would have "
+	}
+	fmt.Fprintf(a.stderr, "%s%d created, %d updated, %d failed\n", prefix, counts[item.ImportCreated], counts[item.ImportUpdated], counts[item.ImportFailed])
+	if counts[item.ImportFailed] > 0 {
+		return wrap(&common.Error{
+			Code:    http.StatusBadRequest,
+			Message: fmt.Sprintf("%d of %d rows failed", counts[item.ImportFailed], len(results)),
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+// Command webflow is a command-line client of the Webflow API.
+//
+//	webflow info
+//	webflow user
+//	webflow sites list
+//	webflow domains list <site>
+//	webflow publish <site> [-domain name]...
+//	webflow items export <collection> [-format csv|json|ndjson] [-refs id|slug] [-file path]
+//	webflow items import <collection> <file.csv> [-mapping path] [-dry-run] [-live]
+//
+// Sites are given by ID or short name, collections by ID or, with -site, by
+// slug. The API token is read from the -token flag, the WEBFLOW_TOKEN
+// environment variable or the config file, in that order. The config file is
+// JSON, {"token": "...", "baseUrl": "..."}, and lives at -config,
+// WEBFLOW_CONFIG or webflow/config.json in the user config directory. The
+// WEBFLOW_API_URL environment variable overrides the API base URL, e.g. to
+// talk to a test server. Arguments after "--" are never read as flags.
+//
+// Output is a table, or JSON with -output json. The exit status tells what
+// went wrong:
+//
+//	0  success
+//	1  other errors
+//	2  invalid usage or configuration
+//	3  token missing, invalid or without access
+//	4  site or resource not found
+//	5  request or imported row rejected by validation or conflicting with
+//	   the current state
+//	6  rate limited
+//	7  Webflow server error
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+)
+
+const usage = `usage: webflow <command> [flags] [args]
+
+commands:
+  info                       show the authorization of the token
+  user                       show the user of the token
+  sites list                 list the sites of the token
+  domains list <site>        list the custom domains of a site
+  publish <site>             publish a site
+  items export <collection>  export the items of a collection as CSV or JSON
+  items import <collection> <file.csv>
+                             create or update the items of a collection from CSV
+
+flags:
+  -token string    API token, instead of WEBFLOW_TOKEN or the config file
+  -config string   config file path
+  -output string   output format, table or json (default "table")
+
+environment:
+  WEBFLOW_TOKEN    API token, instead of the config file
+  WEBFLOW_CONFIG   config file path, instead of webflow/config.json in the
+                   user config directory
+  WEBFLOW_API_URL  API base URL, instead of the config file or
+                   https://api.webflow.com
+
+Run webflow <command> -h for the flags of a command. Arguments after --
+are never read as flags.
+`
+
+// command runs a command with the arguments following its name.
+type command func(ctx context.Context, a *app, args []string) error
+
+var commands = map[string]command{
+	"info":         infoCommand,
+	"user":         userCommand,
+	"sites list":   sitesListCommand,
+	"domains list": domainsListCommand,
+	"publish":      publishCommand,
+	"items export": itemsExportCommand,
+	"items import": itemsImportCommand,
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr, os.Getenv))
+}
+
+// run executes the command line args and returns the exit status.
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer, getenv func(string) string) int {
+	name, cmd, rest := lookup(args)
+	if cmd == nil {
+		if len(args) > 0 && args[0] != "help" && args[0] != "-h" && args[0] != "--help" {
+			fmt.Fprintf(stderr, "webflow: unknown command %q\n\n", strings.Join(args, " "))
+			fmt.Fprint(stderr, usage)
+			return exitUsage
+		}
+
+		fmt.Fprint(stdout, usage)
+		return exitOK
+	}
+
+	a := &app{name: name, stdout: stdout, stderr: stderr, getenv: getenv}
+	if err := cmd(ctx, a, rest); err != nil {
+		return a.fail(err)
+	}
+
+	return exitOK
+}
+
+// lookup finds the longest command name args start with.
+func lookup(args []string) (string, command, []string) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+
+	for _, name := range names {
+		words := strings.Fields(name)
+		if len(args) < len(words) || strings.Join(args[:len(words)], " ") != name {
+			continue
+		}
+
+		return name, commands[name], args[len(words):]
+	}
+
+	return "", nil, nil
+}
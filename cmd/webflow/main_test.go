@@ -0,0 +1,252 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nasrul21/go-webflow/fixtures"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/nasrul21/go-webflow/webflowtest"
+	"github.com/stretchr/testify/assert"
+)
+
+type result struct {
+	code   int
+	stdout string
+	stderr string
+}
+
+func newServer(t *testing.T) (*webflowtest.Server, model.Site) {
+	site := fixtures.Site(func(s *model.Site) { s.ShortName = "shop" })
+	srv := webflowtest.NewServer(&webflowtest.Fixtures{
+		User:    &model.AuthorizedUser{User: model.AuthorizedUserDetail{ID: fixtures.ID(), Email: "arthur.dent@example.com", FirstName: "Arthur", LastName: "Dent"}},
+		Sites:   []model.Site{site},
+		Domains: map[string][]model.Domain{site.ID: {fixtures.Domain(func(d *model.Domain) { d.Name = "shop.example.com" })}},
+	})
+	t.Cleanup(srv.Close)
+
+	return srv, site
+}
+
+func runWith(env map[string]string, args ...string) result {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, &stdout, &stderr, func(key string) string { return env[key] })
+
+	return result{code: code, stdout: stdout.String(), stderr: stderr.String()}
+}
+
+// serverEnv points the CLI at srv, with an empty config file so that the
+// one of the user running the tests is not read.
+func serverEnv(t *testing.T, srv *webflowtest.Server) map[string]string {
+	path := filepath.Join(t.TempDir(), "config.json")
+	assert.Nil(t, os.WriteFile(path, []byte("{}"), 0o600))
+
+	return map[string]string{
+		"WEBFLOW_TOKEN":   srv.Token,
+		"WEBFLOW_API_URL": srv.URL,
+		"WEBFLOW_CONFIG":  path,
+	}
+}
+
+func TestCommands(t *testing.T) {
+	srv, site := newServer(t)
+	env := serverEnv(t, srv)
+
+	res := runWith(env, "sites", "list")
+	assert.Equal(t, exitOK, res.code)
+	assert.Contains(t, res.stdout, "SHORT NAME")
+	assert.Contains(t, res.stdout, site.ID+"  shop")
+
+	res = runWith(env, "user", "-output", "json")
+	assert.Equal(t, exitOK, res.code)
+	var user model.AuthorizedUser
+	assert.Nil(t, json.Unmarshal([]byte(res.stdout), &user))
+	assert.Equal(t, "arthur.dent@example.com", user.User.Email)
+
+	res = runWith(env, "info")
+	assert.Equal(t, exitOK, res.code)
+	assert.Contains(t, res.stdout, site.ID)
+
+	res = runWith(env, "domains", "list", "shop")
+	assert.Equal(t, exitOK, res.code)
+	assert.Contains(t, res.stdout, "shop.example.com")
+
+	res = runWith(env, "publish", "shop", "-output", "json")
+	assert.Equal(t, exitOK, res.code)
+	assert.JSONEq(t, `{"queued": true}`, res.stdout)
+
+	requests := srv.Requests()
+	var body model.PublishSiteRequest
+	assert.Nil(t, json.Unmarshal(requests[len(requests)-1].Body, &body))
+	assert.Equal(t, []string{"shop.example.com", "shop.webflow.io"}, body.Domains)
+}
+
+func TestExitCodes(t *testing.T) {
+	srv, _ := newServer(t)
+	env := serverEnv(t, srv)
+
+	res := runWith(env, "domains", "list", "unknown")
+	assert.Equal(t, exitNotFound, res.code)
+	assert.Contains(t, res.stderr, `site "unknown" not found`)
+
+	res = runWith(env, "domains", "list", "-output", "json", "--", "-token")
+	assert.Equal(t, exitNotFound, res.code)
+	assert.Contains(t, res.stderr, `site "-token" not found`)
+
+	res = runWith(env, "publish", "shop", "-domain", "example.com")
+	assert.Equal(t, exitInvalid, res.code)
+	assert.Contains(t, res.stderr, "Unknown domain 'example.com'")
+
+	res = runWith(env, "sites", "list", "-token", "wrong")
+	assert.Equal(t, exitAuth, res.code)
+
+	srv.Inject(webflowtest.FailRoute(http.MethodGet, "/sites", http.StatusServiceUnavailable))
+	res = runWith(env, "sites", "list")
+	assert.Equal(t, exitServer, res.code)
+
+	srv.ClearFaults()
+	srv.Inject(webflowtest.RateLimit(0, 0))
+	res = runWith(env, "user")
+	assert.Equal(t, exitRateLimited, res.code)
+
+	res = runWith(env, "sites", "delete")
+	assert.Equal(t, exitUsage, res.code)
+
+	res = runWith(env, "domains", "list")
+	assert.Equal(t, exitUsage, res.code)
+	assert.Contains(t, res.stderr, "takes 1 arguments, got 0")
+
+	res = runWith(map[string]string{"WEBFLOW_CONFIG": env["WEBFLOW_CONFIG"]}, "user")
+	assert.Equal(t, exitAuth, res.code)
+	assert.Contains(t, res.stderr, "no API token")
+}
+
+func TestConfigFile(t *testing.T) {
+	srv, _ := newServer(t)
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	data, _ := json.Marshal(config{Token: srv.Token, BaseURL: srv.URL})
+	assert.Nil(t, os.WriteFile(path, data, 0o600))
+
+	res := runWith(nil, "sites", "list", "-config", path)
+	assert.Equal(t, exitOK, res.code)
+	assert.Contains(t, res.stdout, "shop")
+
+	res = runWith(map[string]string{"WEBFLOW_CONFIG": path, "WEBFLOW_TOKEN": "wrong"}, "sites", "list")
+	assert.Equal(t, exitAuth, res.code)
+
+	res = runWith(nil, "sites", "list", "-config", filepath.Join(t.TempDir(), "missing.json"))
+	assert.Equal(t, exitUsage, res.code)
+}
+
+func TestItemsExport(t *testing.T) {
+	srv, site := newServer(t)
+	authors := fixtures.Collection(func(c *model.Collection) { c.Slug = "authors" })
+	author := fixtures.Item(authors, func(i *model.Item) { i.Slug = "arthur-dent" })
+	posts := fixtures.Collection(
+		func(c *model.Collection) { c.Slug = "posts" },
+		fixtures.WithFields(
+			fixtures.Field("author", model.FieldTypeItemRef, func(f *model.CollectionField) {
+				f.Validations = map[string]interface{}{"collectionId": authors.ID}
+			}),
+			fixtures.Field("main-image", model.FieldTypeImage),
+		),
+	)
+	post := fixtures.Item(posts,
+		fixtures.WithField("author", author.ID),
+		fixtures.WithField("main-image", map[string]interface{}{"fileId": "f1", "url": "https://uploads.webflow.com/f1.png"}),
+	)
+	srv.Seed(&webflowtest.Fixtures{
+		Sites:       []model.Site{site},
+		Collections: map[string][]model.Collection{site.ID: {authors, posts}},
+		Items:       map[string][]model.Item{authors.ID: {author}, posts.ID: {post}},
+	})
+	env := serverEnv(t, srv)
+
+	res := runWith(env, "items", "export", posts.ID, "-refs", "slug")
+	assert.Equal(t, exitOK, res.code)
+	assert.Contains(t, res.stdout, "_id,name,slug,author,main-image,_archived")
+	assert.Contains(t, res.stdout, ",arthur-dent,https://uploads.webflow.com/f1.png,false,")
+
+	path := filepath.Join(t.TempDir(), "posts.ndjson")
+	res = runWith(env, "items", "export", "posts", "-site", "shop", "-format", "ndjson", "-file", path)
+	assert.Equal(t, exitOK, res.code)
+	assert.Contains(t, res.stderr, "exported 1 items of posts")
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	var exported map[string]interface{}
+	assert.Nil(t, json.Unmarshal(data, &exported))
+	assert.Equal(t, author.ID, exported["author"])
+
+	res = runWith(env, "items", "export", "drafts", "-site", "shop")
+	assert.Equal(t, exitNotFound, res.code)
+
+	res = runWith(env, "items", "export", posts.ID, "-format", "xml")
+	assert.Equal(t, exitUsage, res.code)
+
+	srv.Inject(webflowtest.RateLimit(1, 0))
+	res = runWith(env, "items", "export", posts.ID, "-file", path)
+	assert.Equal(t, exitRateLimited, res.code)
+	_, statErr := os.Stat(path)
+	assert.True(t, os.IsNotExist(statErr))
+}
+
+func TestItemsImport(t *testing.T) {
+	srv, site := newServer(t)
+	authors := fixtures.Collection(func(c *model.Collection) { c.Slug = "authors" })
+	author := fixtures.Item(authors, func(i *model.Item) { i.Slug = "arthur-dent" })
+	posts := fixtures.Collection(
+		func(c *model.Collection) { c.Slug = "posts" },
+		fixtures.WithFields(
+			fixtures.Field("author", model.FieldTypeItemRef, func(f *model.CollectionField) {
+				f.Validations = map[string]interface{}{"collectionId": authors.ID}
+			}),
+			fixtures.Field("reading-time", model.FieldTypeNumber),
+		),
+	)
+	post := fixtures.Item(posts, func(i *model.Item) { i.Slug = "hello-world" })
+	srv.Seed(&webflowtest.Fixtures{
+		Sites:       []model.Site{site},
+		Collections: map[string][]model.Collection{site.ID: {authors, posts}},
+		Items:       map[string][]model.Item{authors.ID: {author}, posts.ID: {post}},
+	})
+	env := serverEnv(t, srv)
+
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "posts.csv")
+	assert.Nil(t, os.WriteFile(csvPath, []byte("Title,Writer,Minutes\nHello World,arthur-dent,3\nSo Long,arthur-dent,5\nBroken,zaphod,\n"), 0o600))
+	mappingPath := filepath.Join(dir, "mapping.json")
+	assert.Nil(t, os.WriteFile(mappingPath, []byte(`{"columns": [
+		{"column": "Title", "field": "name"},
+		{"column": "Writer", "field": "author"},
+		{"column": "Minutes", "field": "reading-time"}
+	]}`), 0o600))
+
+	res := runWith(env, "items", "import", "posts", csvPath, "-site", "shop", "-mapping", mappingPath, "-dry-run")
+	assert.Equal(t, exitInvalid, res.code)
+	assert.Contains(t, res.stdout, "2     hello-world  updated")
+	assert.Contains(t, res.stdout, "Referenced item 'zaphod' not found")
+	assert.Contains(t, res.stderr, "dry run: would have 1 created, 1 updated, 1 failed")
+	assert.Len(t, srv.Snapshot().Items[posts.ID], 1)
+
+	res = runWith(env, "items", "import", posts.ID, csvPath, "-mapping", mappingPath, "-output", "json")
+	assert.Equal(t, exitInvalid, res.code)
+	var report []importRow
+	assert.Nil(t, json.Unmarshal([]byte(res.stdout), &report))
+	assert.Equal(t, "so-long", report[1].Slug)
+	assert.NotEmpty(t, report[1].ItemID)
+	assert.Equal(t, []interface{}{"Field 'author': Referenced item 'zaphod' not found"}, report[2].Problems)
+
+	items := srv.Snapshot().Items[posts.ID]
+	assert.Len(t, items, 2)
+	assert.Equal(t, author.ID, items[0].Fields["author"])
+	assert.Equal(t, float64(3), items[0].Fields["reading-time"])
+
+	res = runWith(env, "items", "import", posts.ID, csvPath, "-mapping", filepath.Join(dir, "missing.json"))
+	assert.Equal(t, exitUsage, res.code)
+}
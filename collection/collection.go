@@ -0,0 +1,80 @@
+package collection
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/nasrul21/go-webflow/client"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+)
+
+type Collection interface {
+	GetList(siteID string) ([]model.Collection, *common.Error)
+	GetListWithContext(ctx context.Context, siteID string) ([]model.Collection, *common.Error)
+	Get(collectionID string) (*model.Collection, *common.Error)
+	GetWithContext(ctx context.Context, collectionID string) (*model.Collection, *common.Error)
+}
+
+type CollectionImpl struct {
+	Opt    *common.Option
+	Client client.Client
+}
+
+func New(opt *common.Option, client client.Client) Collection {
+	return &CollectionImpl{
+		Opt:    opt,
+		Client: client,
+	}
+}
+
+// GetList returns the collections of a site, without their fields.
+func (c *CollectionImpl) GetList(siteID string) ([]model.Collection, *common.Error) {
+	return c.GetListWithContext(context.Background(), siteID)
+}
+
+func (c *CollectionImpl) GetListWithContext(ctx context.Context, siteID string) ([]model.Collection, *common.Error) {
+	response := []model.Collection{}
+	var header http.Header
+
+	err := c.Client.Call(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/sites/%s/collections", c.Opt.BaseURL, siteID),
+		c.Opt.ApiKey,
+		header,
+		nil,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Get returns a collection with its full schema.
+func (c *CollectionImpl) Get(collectionID string) (*model.Collection, *common.Error) {
+	return c.GetWithContext(context.Background(), collectionID)
+}
+
+func (c *CollectionImpl) GetWithContext(ctx context.Context, collectionID string) (*model.Collection, *common.Error) {
+	var response model.Collection
+	var header http.Header
+
+	err := c.Client.Call(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/collections/%s", c.Opt.BaseURL, collectionID),
+		c.Opt.ApiKey,
+		header,
+		nil,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
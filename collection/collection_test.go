@@ -0,0 +1,196 @@
+package collection_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/nasrul21/go-webflow"
+	"github.com/nasrul21/go-webflow/client/mock"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetList(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		resultString := `[
+			{
+				"_id": "580e63fc8c9a982ac9b8b745",
+				"name": "Blog Posts",
+				"slug": "post",
+				"singularName": "Blog Post"
+			}
+		]`
+
+		_ = json.Unmarshal([]byte(resultString), &result)
+
+		return nil
+	}
+
+	siteID := "580e63e98c9a982ac9b8b741"
+
+	testcases := []struct {
+		desc        string
+		mockClosure func()
+		expectedRes []model.Collection
+		expectedErr *common.Error
+	}{
+		{
+			desc: "should get list collections",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/sites/%s/collections", wf.Opt.BaseURL, siteID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&[]model.Collection{},
+				).Return(nil).Once()
+			},
+			expectedRes: []model.Collection{
+				{
+					ID:           "580e63fc8c9a982ac9b8b745",
+					Name:         "Blog Posts",
+					Slug:         "post",
+					SingularName: "Blog Post",
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "should return error",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/sites/%s/collections", wf.Opt.BaseURL, siteID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&[]model.Collection{},
+				).Return(common.FromGoErr(fmt.Errorf("some error"))).Once()
+			},
+			expectedRes: nil,
+			expectedErr: common.FromGoErr(fmt.Errorf("some error")),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.mockClosure()
+
+			resp, err := wf.Collection.GetList(siteID)
+
+			assert.Equal(t, tc.expectedRes, resp)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		resultString := `{
+			"_id": "580e63fc8c9a982ac9b8b745",
+			"name": "Blog Posts",
+			"slug": "post",
+			"singularName": "Blog Post",
+			"fields": [
+				{
+					"id": "7f62a9781291109b9e428fb47239fd35",
+					"type": "ItemRef",
+					"slug": "author",
+					"name": "Author",
+					"required": false,
+					"editable": true,
+					"validations": {"collectionId": "580e63fc8c9a982ac9b8b746"}
+				}
+			]
+		}`
+
+		_ = json.Unmarshal([]byte(resultString), &result)
+
+		return nil
+	}
+
+	collectionID := "580e63fc8c9a982ac9b8b745"
+
+	testcases := []struct {
+		desc        string
+		mockClosure func()
+		expectedRes *model.Collection
+		expectedErr *common.Error
+	}{
+		{
+			desc: "should get collection with schema",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/collections/%s", wf.Opt.BaseURL, collectionID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&model.Collection{},
+				).Return(nil).Once()
+			},
+			expectedRes: &model.Collection{
+				ID:           collectionID,
+				Name:         "Blog Posts",
+				Slug:         "post",
+				SingularName: "Blog Post",
+				Fields: []model.CollectionField{
+					{
+						ID:          "7f62a9781291109b9e428fb47239fd35",
+						Type:        model.FieldTypeItemRef,
+						Slug:        "author",
+						Name:        "Author",
+						Editable:    true,
+						Validations: map[string]interface{}{"collectionId": "580e63fc8c9a982ac9b8b746"},
+					},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "should return error",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/collections/%s", wf.Opt.BaseURL, collectionID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&model.Collection{},
+				).Return(common.FromGoErr(fmt.Errorf("some error"))).Once()
+			},
+			expectedRes: nil,
+			expectedErr: common.FromGoErr(fmt.Errorf("some error")),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.mockClosure()
+
+			resp, err := wf.Collection.Get(collectionID)
+
+			assert.Equal(t, tc.expectedRes, resp)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
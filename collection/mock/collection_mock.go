@@ -0,0 +1,49 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/nasrul21/go-webflow/collection"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// CollectionMock is a testify mock of collection.Collection.
+type CollectionMock struct {
+	mock.Mock
+}
+
+var _ collection.Collection = (*CollectionMock)(nil)
+
+func (m *CollectionMock) GetList(siteID string) ([]model.Collection, *common.Error) {
+	args := m.Called(siteID)
+	res, _ := args.Get(0).([]model.Collection)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *CollectionMock) GetListWithContext(ctx context.Context, siteID string) ([]model.Collection, *common.Error) {
+	args := m.Called(ctx, siteID)
+	res, _ := args.Get(0).([]model.Collection)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *CollectionMock) Get(collectionID string) (*model.Collection, *common.Error) {
+	args := m.Called(collectionID)
+	res, _ := args.Get(0).(*model.Collection)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *CollectionMock) GetWithContext(ctx context.Context, collectionID string) (*model.Collection, *common.Error) {
+	args := m.Called(ctx, collectionID)
+	res, _ := args.Get(0).(*model.Collection)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
@@ -8,6 +8,10 @@ import (
 const (
 	APIValidationError string = "API_VALIDATION_ERROR"
 	GoErrCode          string = "GO_ERROR"
+	UploadErrCode      string = "UPLOAD_ERROR"
+	// InvalidTransitionErrCode marks errors raised on the client side
+	// before calling an API that would reject the change of state.
+	InvalidTransitionErrCode string = "INVALID_TRANSITION"
 )
 
 type Error struct {
@@ -1,6 +1,14 @@
 package common
 
+import "context"
+
 type Option struct {
 	ApiKey  string
 	BaseURL string
 }
+
+// CurrencySource resolves the default currency of a site, used to complete
+// money values the API returned without a unit.
+type CurrencySource interface {
+	DefaultCurrency(ctx context.Context, siteID string) (string, *Error)
+}
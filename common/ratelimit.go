@@ -0,0 +1,101 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Limiter spaces out API calls so that no more than the given number start
+// per minute. Webflow reports the limit of a token in AuthorizationInfo.
+type Limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func NewLimiter(perMinute int) *Limiter {
+	if perMinute <= 0 {
+		perMinute = 60
+	}
+
+	return &Limiter{
+		interval: time.Minute / time.Duration(perMinute),
+	}
+}
+
+// Wait blocks until the caller may start the next call or ctx is done.
+func (l *Limiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	start := l.next
+	if start.Before(now) {
+		start = now
+	}
+	l.next = start.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(start)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func IsRateLimited(err *Error) bool {
+	return err != nil && err.Code == http.StatusTooManyRequests
+}
+
+// RetryPolicy paces calls with Limiter and retries the ones that were rate
+// limited.
+type RetryPolicy struct {
+	// Limiter, when set, is waited on before every attempt.
+	Limiter *Limiter
+	// MaxRetries is how often a rate limited call is retried, 3 when zero.
+	MaxRetries int
+	// Wait is the delay before a retry, multiplied by the attempt number.
+	// One minute when zero.
+	Wait time.Duration
+}
+
+func (p RetryPolicy) Do(ctx context.Context, call func() *Error) *Error {
+	maxRetries := p.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = 3
+	}
+	wait := p.Wait
+	if wait <= 0 {
+		wait = time.Minute
+	}
+
+	for attempt := 0; ; attempt++ {
+		if p.Limiter != nil {
+			if err := p.Limiter.Wait(ctx); err != nil {
+				return FromGoErr(err)
+			}
+		}
+
+		err := call()
+		if !IsRateLimited(err) || attempt >= maxRetries {
+			return err
+		}
+
+		timer := time.NewTimer(wait * time.Duration(attempt+1))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return FromGoErr(ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/domain"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// DomainMock is a testify mock of domain.Domain.
+type DomainMock struct {
+	mock.Mock
+}
+
+var _ domain.Domain = (*DomainMock)(nil)
+
+func (m *DomainMock) GetList(siteID string) ([]model.Domain, *common.Error) {
+	args := m.Called(siteID)
+	res, _ := args.Get(0).([]model.Domain)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *DomainMock) GetListWithContext(ctx context.Context, siteID string) ([]model.Domain, *common.Error) {
+	args := m.Called(ctx, siteID)
+	res, _ := args.Get(0).([]model.Domain)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
@@ -0,0 +1,81 @@
+package ecommerce
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/nasrul21/go-webflow/client"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+)
+
+type Ecommerce interface {
+	GetSettings(siteID string) (*model.EcommerceSettings, *common.Error)
+	GetSettingsWithContext(ctx context.Context, siteID string) (*model.EcommerceSettings, *common.Error)
+	DefaultCurrency(ctx context.Context, siteID string) (string, *common.Error)
+}
+
+type EcommerceImpl struct {
+	Opt    *common.Option
+	Client client.Client
+
+	mu         sync.Mutex
+	currencies map[string]string
+}
+
+func New(opt *common.Option, client client.Client) Ecommerce {
+	return &EcommerceImpl{
+		Opt:        opt,
+		Client:     client,
+		currencies: map[string]string{},
+	}
+}
+
+func (e *EcommerceImpl) GetSettings(siteID string) (*model.EcommerceSettings, *common.Error) {
+	return e.GetSettingsWithContext(context.Background(), siteID)
+}
+
+func (e *EcommerceImpl) GetSettingsWithContext(ctx context.Context, siteID string) (*model.EcommerceSettings, *common.Error) {
+	var response model.EcommerceSettings
+	var header http.Header
+
+	err := e.Client.Call(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/sites/%s/ecommerce/settings", e.Opt.BaseURL, siteID),
+		e.Opt.ApiKey,
+		header,
+		nil,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// DefaultCurrency returns the default currency of a site, fetching the
+// settings once per site and caching the result. It implements
+// common.CurrencySource for the product and order services.
+func (e *EcommerceImpl) DefaultCurrency(ctx context.Context, siteID string) (string, *common.Error) {
+	e.mu.Lock()
+	currency, ok := e.currencies[siteID]
+	e.mu.Unlock()
+	if ok {
+		return currency, nil
+	}
+
+	settings, err := e.GetSettingsWithContext(ctx, siteID)
+	if err != nil {
+		return "", err
+	}
+
+	e.mu.Lock()
+	e.currencies[siteID] = settings.DefaultCurrency
+	e.mu.Unlock()
+
+	return settings.DefaultCurrency, nil
+}
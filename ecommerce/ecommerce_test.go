@@ -0,0 +1,121 @@
+package ecommerce_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nasrul21/go-webflow"
+	"github.com/nasrul21/go-webflow/client/mock"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/stretchr/testify/assert"
+)
+
+const siteID = "580e63e98c9a982ac9b8b741"
+
+func TestGetSettings(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		resultString := `{
+			"site": "580e63e98c9a982ac9b8b741",
+			"createdOn": "2018-10-04T15:22:01.950Z",
+			"defaultCurrency": "USD"
+		}`
+
+		_ = json.Unmarshal([]byte(resultString), &result)
+
+		return nil
+	}
+
+	testcases := []struct {
+		desc        string
+		mockClosure func()
+		expectedRes *model.EcommerceSettings
+		expectedErr *common.Error
+	}{
+		{
+			desc: "should get ecommerce settings",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/sites/%s/ecommerce/settings", wf.Opt.BaseURL, siteID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&model.EcommerceSettings{},
+				).Return(nil).Once()
+			},
+			expectedRes: &model.EcommerceSettings{
+				Site:            siteID,
+				CreatedOn:       time.Date(2018, 10, 4, 15, 22, 1, int(950*time.Millisecond), time.UTC),
+				DefaultCurrency: "USD",
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "should return error",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/sites/%s/ecommerce/settings", wf.Opt.BaseURL, siteID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&model.EcommerceSettings{},
+				).Return(common.FromGoErr(fmt.Errorf("some error"))).Once()
+			},
+			expectedRes: nil,
+			expectedErr: common.FromGoErr(fmt.Errorf("some error")),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.mockClosure()
+
+			resp, err := wf.Ecommerce.GetSettings(siteID)
+
+			assert.Equal(t, tc.expectedRes, resp)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestDefaultCurrency(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		_ = json.Unmarshal([]byte(`{"site": "580e63e98c9a982ac9b8b741", "defaultCurrency": "EUR"}`), &result)
+
+		return nil
+	}
+	httpClientMockObj.On(
+		"Call",
+		context.Background(),
+		http.MethodGet,
+		fmt.Sprintf("%s/sites/%s/ecommerce/settings", wf.Opt.BaseURL, siteID),
+		wf.Opt.ApiKey,
+		http.Header(nil),
+		nil,
+		&model.EcommerceSettings{},
+	).Return(nil).Once()
+
+	for i := 0; i < 2; i++ {
+		currency, err := wf.Ecommerce.DefaultCurrency(context.Background(), siteID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "EUR", currency)
+	}
+	httpClientMockObj.AssertExpectations(t)
+}
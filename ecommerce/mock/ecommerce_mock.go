@@ -0,0 +1,41 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/ecommerce"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// EcommerceMock is a testify mock of ecommerce.Ecommerce.
+type EcommerceMock struct {
+	mock.Mock
+}
+
+var _ ecommerce.Ecommerce = (*EcommerceMock)(nil)
+
+func (m *EcommerceMock) GetSettings(siteID string) (*model.EcommerceSettings, *common.Error) {
+	args := m.Called(siteID)
+	res, _ := args.Get(0).(*model.EcommerceSettings)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *EcommerceMock) GetSettingsWithContext(ctx context.Context, siteID string) (*model.EcommerceSettings, *common.Error) {
+	args := m.Called(ctx, siteID)
+	res, _ := args.Get(0).(*model.EcommerceSettings)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *EcommerceMock) DefaultCurrency(ctx context.Context, siteID string) (string, *common.Error) {
+	args := m.Called(ctx, siteID)
+	res, _ := args.Get(0).(string)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
@@ -0,0 +1,366 @@
+// Package fixtures builds valid model values for tests. Every builder fills
+// in realistic defaults, with IDs shaped like the 24 character hex IDs of
+// Webflow, and applies the given overrides in order:
+//
+//	site := fixtures.Site(func(s *model.Site) { s.Name = "Shop" })
+//	order := fixtures.Order(fixtures.WithItem(fixtures.SKU(product), 2))
+//
+// IDs come from a sequence and timestamps from Now, so the same test builds
+// the same values on every run.
+package fixtures
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/nasrul21/go-webflow/webhook"
+)
+
+// Now is the timestamp given to created, updated and accepted fields.
+var Now = time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+
+var sequence uint64
+
+// ID returns the next ID of the sequence.
+func ID() string {
+	n := atomic.AddUint64(&sequence, 1)
+
+	var b [12]byte
+	binary.BigEndian.PutUint32(b[:4], uint32(Now.Unix()))
+	binary.BigEndian.PutUint64(b[4:], n)
+
+	return hex.EncodeToString(b[:])
+}
+
+// Reset restarts the ID sequence.
+func Reset() {
+	atomic.StoreUint64(&sequence, 0)
+}
+
+func seq(id string) string {
+	return strings.TrimLeft(id[8:], "0")
+}
+
+func now() *time.Time {
+	t := Now
+	return &t
+}
+
+func Site(overrides ...func(*model.Site)) model.Site {
+	id := ID()
+	site := model.Site{
+		ID:            id,
+		CreatedOn:     Now,
+		Name:          "Test Site " + seq(id),
+		ShortName:     "test-site-" + seq(id),
+		LastPublished: Now,
+		PreviewURL:    fmt.Sprintf("https://screenshots.webflow.com/sites/%s/preview.png", id),
+		Timezone:      "UTC",
+		Database:      ID(),
+	}
+	for _, override := range overrides {
+		override(&site)
+	}
+
+	return site
+}
+
+func Domain(overrides ...func(*model.Domain)) model.Domain {
+	id := ID()
+	domain := model.Domain{
+		ID:   id,
+		Name: fmt.Sprintf("test-site-%s.com", seq(id)),
+	}
+	for _, override := range overrides {
+		override(&domain)
+	}
+
+	return domain
+}
+
+// Field returns an optional, editable collection field.
+func Field(slug string, fieldType string, overrides ...func(*model.CollectionField)) model.CollectionField {
+	field := model.CollectionField{
+		ID:       ID(),
+		Type:     fieldType,
+		Slug:     slug,
+		Name:     fieldName(slug),
+		Editable: true,
+	}
+	for _, override := range overrides {
+		override(&field)
+	}
+
+	return field
+}
+
+func fieldName(slug string) string {
+	words := strings.Split(slug, "-")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+// Collection returns a collection with the name and slug fields every
+// collection has. Add schema fields with WithFields.
+func Collection(overrides ...func(*model.Collection)) model.Collection {
+	id := ID()
+	collection := model.Collection{
+		ID:           id,
+		LastUpdated:  now(),
+		CreatedOn:    now(),
+		Name:         "Posts " + seq(id),
+		Slug:         "posts-" + seq(id),
+		SingularName: "Post",
+		Fields: []model.CollectionField{
+			Field("name", model.FieldTypePlainText, func(f *model.CollectionField) { f.Required = true }),
+			Field("slug", model.FieldTypePlainText, func(f *model.CollectionField) { f.Required = true }),
+		},
+	}
+	for _, override := range overrides {
+		override(&collection)
+	}
+
+	return collection
+}
+
+func WithFields(fields ...model.CollectionField) func(*model.Collection) {
+	return func(c *model.Collection) {
+		c.Fields = append(c.Fields, fields...)
+	}
+}
+
+// Item returns a published item of collection.
+func Item(collection model.Collection, overrides ...func(*model.Item)) model.Item {
+	id := ID()
+	item := model.Item{
+		ID:           id,
+		CollectionID: collection.ID,
+		Name:         "Item " + seq(id),
+		Slug:         "item-" + seq(id),
+		CreatedOn:    now(),
+		UpdatedOn:    now(),
+		PublishedOn:  now(),
+		Fields:       map[string]interface{}{},
+	}
+	for _, override := range overrides {
+		override(&item)
+	}
+
+	return item
+}
+
+// WithField sets a schema field of an item.
+func WithField(slug string, value interface{}) func(*model.Item) {
+	return func(i *model.Item) {
+		i.Fields[slug] = value
+	}
+}
+
+func Product(overrides ...func(*model.Product)) model.Product {
+	id := ID()
+	product := model.Product{
+		ID:           id,
+		CollectionID: ID(),
+		Name:         "Product " + seq(id),
+		Slug:         "product-" + seq(id),
+		Shippable:    true,
+		TaxCategory:  "standard-taxable",
+		ProductType:  "Physical",
+		CreatedOn:    now(),
+		UpdatedOn:    now(),
+		PublishedOn:  now(),
+	}
+	for _, override := range overrides {
+		override(&product)
+	}
+
+	return product
+}
+
+// SKU returns a SKU of product priced 20.00 USD.
+func SKU(product model.Product, overrides ...func(*model.SKU)) model.SKU {
+	id := ID()
+	sku := model.SKU{
+		ID:           id,
+		CollectionID: ID(),
+		Name:         product.Name,
+		Slug:         product.Slug + "-" + seq(id),
+		Product:      product.ID,
+		Price:        model.NewMoney(2000, "USD"),
+		SKU:          strings.ToUpper(product.Slug) + "-" + seq(id),
+		CreatedOn:    now(),
+		UpdatedOn:    now(),
+		PublishedOn:  now(),
+	}
+	for _, override := range overrides {
+		override(&sku)
+	}
+
+	return sku
+}
+
+// ProductWithSKUs returns product with its SKUs, the first one being the
+// default SKU. A SKU is built when none is given.
+func ProductWithSKUs(product model.Product, skus ...model.SKU) model.ProductWithSKUs {
+	if len(skus) == 0 {
+		skus = []model.SKU{SKU(product)}
+	}
+	product.DefaultSKU = skus[0].ID
+
+	return model.ProductWithSKUs{Product: product, SKUs: skus}
+}
+
+// Order returns an unfulfilled order without items. Add items with
+// WithItem and extras with WithExtra, which keep the totals in step; an
+// override setting an amount directly is kept as is.
+func Order(overrides ...func(*model.Order)) model.Order {
+	id := ID()
+	zero := model.Money{Unit: "USD"}
+	order := model.Order{
+		OrderID:    id[len(id)-6:len(id)-3] + "-" + id[len(id)-3:],
+		Status:     model.OrderStatusUnfulfilled,
+		AcceptedOn: now(),
+		CustomerInfo: model.OrderCustomerInfo{
+			FullName: "Arthur Dent",
+			Email:    "arthur.dent@example.com",
+		},
+		ShippingAddress: &model.OrderAddress{
+			Type:       "shipping",
+			Addressee:  "Arthur Dent",
+			Line1:      "20 Avenue Rd",
+			City:       "London",
+			Country:    "GB",
+			PostalCode: "NW8 6BU",
+		},
+		PaymentProcessor:   "stripe",
+		IsShippingRequired: true,
+		Totals:             model.OrderTotals{Subtotal: zero, Total: zero},
+		CustomerPaid:       zero,
+		NetAmount:          zero,
+	}
+	for _, override := range overrides {
+		override(&order)
+	}
+
+	return order
+}
+
+// WithItem adds count units of sku to an order and to its totals. The first
+// item sets the currency of the totals.
+func WithItem(sku model.SKU, count int) func(*model.Order) {
+	return func(o *model.Order) {
+		rowTotal := sku.Price.Mul(int64(count))
+		if len(o.PurchasedItems) == 0 {
+			for _, amount := range []*model.Money{&o.Totals.Subtotal, &o.Totals.Total, &o.CustomerPaid, &o.NetAmount} {
+				amount.Unit = rowTotal.Unit
+			}
+		}
+
+		o.PurchasedItems = append(o.PurchasedItems, model.OrderPurchasedItem{
+			Count:        count,
+			RowTotal:     rowTotal,
+			ProductID:    sku.Product,
+			ProductName:  sku.Name,
+			VariantID:    sku.ID,
+			VariantName:  sku.Name,
+			VariantSlug:  sku.Slug,
+			VariantSKU:   sku.SKU,
+			VariantPrice: sku.Price,
+		})
+		o.PurchasedItemsCount += count
+		o.Totals.Subtotal.Value += rowTotal.Value
+		addTotal(o, rowTotal)
+	}
+}
+
+// WithExtra adds a tax, shipping or discount line to the totals of an
+// order and its price to the total.
+func WithExtra(extraType string, name string, price model.Money) func(*model.Order) {
+	return func(o *model.Order) {
+		o.Totals.Extras = append(o.Totals.Extras, model.OrderTotalExtra{
+			Type:  extraType,
+			Name:  name,
+			Price: price,
+		})
+		addTotal(o, price)
+	}
+}
+
+func addTotal(o *model.Order, amount model.Money) {
+	o.Totals.Total.Value += amount.Value
+	o.CustomerPaid.Value += amount.Value
+	o.NetAmount.Value += amount.Value
+}
+
+// WithStatus sets the status of an order and the matching timestamp.
+func WithStatus(status string) func(*model.Order) {
+	return func(o *model.Order) {
+		o.Status = status
+		switch status {
+		case model.OrderStatusFulfilled:
+			o.FulfilledOn = now()
+		case model.OrderStatusRefunded:
+			o.RefundedOn = now()
+		}
+	}
+}
+
+// Webhook returns a webhook registered on site for triggerType.
+func Webhook(siteID string, triggerType string, overrides ...func(*model.Webhook)) model.Webhook {
+	webhook := model.Webhook{
+		ID:          ID(),
+		TriggerType: triggerType,
+		TriggerID:   siteID,
+		Site:        siteID,
+		URL:         "https://example.com/webhooks",
+		CreatedOn:   now(),
+	}
+	for _, override := range overrides {
+		override(&webhook)
+	}
+
+	return webhook
+}
+
+// WebhookEvent returns the event a receiver records for payload, which is
+// encoded to JSON. Its ID is the one the receiver derives from the trigger
+// type and payload when no ID header is configured.
+func WebhookEvent(triggerType string, payload interface{}, overrides ...func(*model.WebhookEvent)) model.WebhookEvent {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		panic("fixtures: webhook payload cannot be encoded: " + err.Error())
+	}
+
+	event := model.WebhookEvent{
+		ID:          webhook.EventID(triggerType, data),
+		TriggerType: triggerType,
+		Payload:     data,
+		ReceivedAt:  Now,
+	}
+	for _, override := range overrides {
+		override(&event)
+	}
+
+	return event
+}
+
+func FormSubmission(siteID string, data map[string]interface{}) model.FormSubmission {
+	return model.FormSubmission{
+		ID:   ID(),
+		Name: "Contact Form",
+		Site: siteID,
+		Data: data,
+		Date: Now,
+	}
+}
@@ -0,0 +1,115 @@
+package fixtures_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/nasrul21/go-webflow/fixtures"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/nasrul21/go-webflow/webflowtest"
+	"github.com/nasrul21/go-webflow/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestID(t *testing.T) {
+	fixtures.Reset()
+
+	first := fixtures.ID()
+	assert.Len(t, first, 24)
+	assert.Equal(t, fmt.Sprintf("%08x%016x", fixtures.Now.Unix(), 1), first)
+	assert.NotEqual(t, first, fixtures.ID())
+
+	fixtures.Reset()
+	assert.Equal(t, first, fixtures.ID())
+}
+
+func TestOverrides(t *testing.T) {
+	site := fixtures.Site(func(s *model.Site) { s.Name = "Shop" })
+	assert.Equal(t, "Shop", site.Name)
+	assert.NotEmpty(t, site.ID)
+
+	collection := fixtures.Collection(fixtures.WithFields(fixtures.Field("reading-time", model.FieldTypeNumber)))
+	assert.Len(t, collection.Fields, 3)
+	assert.Equal(t, "Reading Time", collection.Field("reading-time").Name)
+
+	item := fixtures.Item(collection, fixtures.WithField("reading-time", 3))
+	assert.Equal(t, collection.ID, item.CollectionID)
+	assert.Equal(t, 3, item.Fields["reading-time"])
+}
+
+func TestOrder(t *testing.T) {
+	product := fixtures.Product()
+	sku := fixtures.SKU(product, func(s *model.SKU) { s.Price = model.NewMoney(1500, "EUR") })
+
+	order := fixtures.Order(
+		fixtures.WithItem(sku, 2),
+		fixtures.WithExtra("shipping", "Flat", model.NewMoney(500, "EUR")),
+		fixtures.WithStatus(model.OrderStatusFulfilled),
+	)
+
+	assert.Regexp(t, `^[0-9a-f]{3}-[0-9a-f]{3}$`, order.OrderID)
+	assert.Equal(t, 2, order.PurchasedItemsCount)
+	assert.Equal(t, model.NewMoney(3000, "EUR"), order.Totals.Subtotal)
+	assert.Equal(t, model.NewMoney(3500, "EUR"), order.Totals.Total)
+	assert.Equal(t, model.NewMoney(3500, "EUR"), order.CustomerPaid)
+	assert.Equal(t, product.ID, order.PurchasedItems[0].ProductID)
+	assert.NotNil(t, order.FulfilledOn)
+
+	refunded := fixtures.Order(
+		fixtures.WithItem(sku, 2),
+		func(o *model.Order) {
+			o.NetAmount = model.NewMoney(2900, "EUR")
+			o.Totals.Total = model.NewMoney(3100, "EUR")
+		},
+	)
+
+	assert.Equal(t, model.NewMoney(3000, "EUR"), refunded.Totals.Subtotal)
+	assert.Equal(t, model.NewMoney(3100, "EUR"), refunded.Totals.Total)
+	assert.Equal(t, model.NewMoney(3000, "EUR"), refunded.CustomerPaid)
+	assert.Equal(t, model.NewMoney(2900, "EUR"), refunded.NetAmount)
+}
+
+func TestWebhookEvent(t *testing.T) {
+	submission := fixtures.FormSubmission("580e63e98c9a982ac9b8b741", map[string]interface{}{"email": "arthur.dent@example.com"})
+	event := fixtures.WebhookEvent(model.TriggerFormSubmission, submission)
+
+	assert.Equal(t, webhook.EventID(event.TriggerType, event.Payload), event.ID)
+
+	typed, err := webhook.Decode(event)
+	assert.Nil(t, err)
+	assert.Equal(t, submission.Site, typed.Data.(*model.FormSubmission).Site)
+}
+
+func TestFakeServer(t *testing.T) {
+	site := fixtures.Site()
+	collection := fixtures.Collection(fixtures.WithFields(fixtures.Field("author", model.FieldTypePlainText)))
+	product := fixtures.Product()
+
+	srv := webflowtest.NewServer(&webflowtest.Fixtures{
+		Sites:       []model.Site{site},
+		Domains:     map[string][]model.Domain{site.ID: {fixtures.Domain()}},
+		Collections: map[string][]model.Collection{site.ID: {collection}},
+		Items:       map[string][]model.Item{collection.ID: {fixtures.Item(collection, fixtures.WithField("author", "Ford Prefect"))}},
+		Products:    map[string][]model.ProductWithSKUs{site.ID: {fixtures.ProductWithSKUs(product)}},
+		Orders:      map[string][]model.Order{site.ID: {fixtures.Order(fixtures.WithItem(fixtures.SKU(product), 1))}},
+	})
+	defer srv.Close()
+	wf := srv.Webflow()
+
+	domains, err := wf.Domain.GetList(site.ID)
+	assert.Nil(t, err)
+	assert.Len(t, domains, 1)
+
+	products, err := wf.Product.GetList(site.ID, model.PaginationParams{})
+	assert.Nil(t, err)
+	assert.Equal(t, products.Items[0].SKUs[0].ID, products.Items[0].Product.DefaultSKU)
+
+	orders, err := wf.Order.GetList(site.ID, model.OrderListParams{})
+	assert.Nil(t, err)
+	assert.Equal(t, model.NewMoney(2000, "USD"), orders[0].Totals.Total)
+
+	resp, _ := srv.Client().Get(fmt.Sprintf("%s/collections/%s/items", srv.URL, collection.ID))
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp.Body.Close()
+}
@@ -0,0 +1,78 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/nasrul21/go-webflow/client"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+)
+
+type Inventory interface {
+	Get(collectionID string, skuID string) (*model.Inventory, *common.Error)
+	GetWithContext(ctx context.Context, collectionID string, skuID string) (*model.Inventory, *common.Error)
+	Update(collectionID string, skuID string, req model.UpdateInventoryRequest) (*model.Inventory, *common.Error)
+	UpdateWithContext(ctx context.Context, collectionID string, skuID string, req model.UpdateInventoryRequest) (*model.Inventory, *common.Error)
+}
+
+type InventoryImpl struct {
+	Opt    *common.Option
+	Client client.Client
+}
+
+func New(opt *common.Option, client client.Client) Inventory {
+	return &InventoryImpl{
+		Opt:    opt,
+		Client: client,
+	}
+}
+
+func (i *InventoryImpl) Get(collectionID string, skuID string) (*model.Inventory, *common.Error) {
+	return i.GetWithContext(context.Background(), collectionID, skuID)
+}
+
+func (i *InventoryImpl) GetWithContext(ctx context.Context, collectionID string, skuID string) (*model.Inventory, *common.Error) {
+	var response model.Inventory
+	var header http.Header
+
+	err := i.Client.Call(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/collections/%s/items/%s/inventory", i.Opt.BaseURL, collectionID, skuID),
+		i.Opt.ApiKey,
+		header,
+		nil,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (i *InventoryImpl) Update(collectionID string, skuID string, req model.UpdateInventoryRequest) (*model.Inventory, *common.Error) {
+	return i.UpdateWithContext(context.Background(), collectionID, skuID, req)
+}
+
+func (i *InventoryImpl) UpdateWithContext(ctx context.Context, collectionID string, skuID string, req model.UpdateInventoryRequest) (*model.Inventory, *common.Error) {
+	var response model.Inventory
+	var header http.Header
+
+	err := i.Client.Call(
+		ctx,
+		http.MethodPatch,
+		fmt.Sprintf("%s/collections/%s/items/%s/inventory", i.Opt.BaseURL, collectionID, skuID),
+		i.Opt.ApiKey,
+		header,
+		req,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
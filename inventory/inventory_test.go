@@ -0,0 +1,143 @@
+package inventory_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/nasrul21/go-webflow"
+	"github.com/nasrul21/go-webflow/client/mock"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	collectionID = "5eb9fcfccaef497de7757179"
+	skuID        = "5eb9fd05caef491eb9757187"
+)
+
+func TestGet(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		resultString := `{"_id": "5eb9fd05caef491eb9757187", "quantity": 100, "inventoryType": "finite"}`
+
+		_ = json.Unmarshal([]byte(resultString), &result)
+
+		return nil
+	}
+
+	testcases := []struct {
+		desc        string
+		mockClosure func()
+		expectedRes *model.Inventory
+		expectedErr *common.Error
+	}{
+		{
+			desc: "should get inventory",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/collections/%s/items/%s/inventory", wf.Opt.BaseURL, collectionID, skuID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&model.Inventory{},
+				).Return(nil).Once()
+			},
+			expectedRes: &model.Inventory{ID: skuID, Quantity: 100, InventoryType: model.InventoryTypeFinite},
+			expectedErr: nil,
+		},
+		{
+			desc: "should return error",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/collections/%s/items/%s/inventory", wf.Opt.BaseURL, collectionID, skuID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&model.Inventory{},
+				).Return(common.FromGoErr(fmt.Errorf("some error"))).Once()
+			},
+			expectedRes: nil,
+			expectedErr: common.FromGoErr(fmt.Errorf("some error")),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.mockClosure()
+
+			resp, err := wf.Inventory.Get(collectionID, skuID)
+
+			assert.Equal(t, tc.expectedRes, resp)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		resultString := `{"_id": "5eb9fd05caef491eb9757187", "quantity": 98, "inventoryType": "finite"}`
+
+		_ = json.Unmarshal([]byte(resultString), &result)
+
+		return nil
+	}
+
+	testcases := []struct {
+		desc         string
+		req          model.UpdateInventoryRequest
+		expectedBody string
+	}{
+		{
+			desc:         "should set quantity",
+			req:          model.SetInventoryQuantity(98),
+			expectedBody: `{"fields": {"inventoryType": "finite", "quantity": 98}}`,
+		},
+		{
+			desc:         "should adjust quantity",
+			req:          model.AdjustInventoryQuantity(-2),
+			expectedBody: `{"fields": {"inventoryType": "finite", "updateQuantity": -2}}`,
+		},
+		{
+			desc:         "should set infinite inventory",
+			req:          model.InfiniteInventory(),
+			expectedBody: `{"fields": {"inventoryType": "infinite"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			httpClientMockObj.On(
+				"Call",
+				context.Background(),
+				http.MethodPatch,
+				fmt.Sprintf("%s/collections/%s/items/%s/inventory", wf.Opt.BaseURL, collectionID, skuID),
+				wf.Opt.ApiKey,
+				http.Header(nil),
+				tc.req,
+				&model.Inventory{},
+			).Return(nil).Once()
+
+			resp, err := wf.Inventory.Update(collectionID, skuID, tc.req)
+
+			body, _ := json.Marshal(tc.req)
+			assert.JSONEq(t, tc.expectedBody, string(body))
+			assert.Nil(t, err)
+			assert.Equal(t, &model.Inventory{ID: skuID, Quantity: 98, InventoryType: model.InventoryTypeFinite}, resp)
+		})
+	}
+}
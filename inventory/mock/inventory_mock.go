@@ -0,0 +1,49 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/inventory"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// InventoryMock is a testify mock of inventory.Inventory.
+type InventoryMock struct {
+	mock.Mock
+}
+
+var _ inventory.Inventory = (*InventoryMock)(nil)
+
+func (m *InventoryMock) Get(collectionID string, skuID string) (*model.Inventory, *common.Error) {
+	args := m.Called(collectionID, skuID)
+	res, _ := args.Get(0).(*model.Inventory)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *InventoryMock) GetWithContext(ctx context.Context, collectionID string, skuID string) (*model.Inventory, *common.Error) {
+	args := m.Called(ctx, collectionID, skuID)
+	res, _ := args.Get(0).(*model.Inventory)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *InventoryMock) Update(collectionID string, skuID string, req model.UpdateInventoryRequest) (*model.Inventory, *common.Error) {
+	args := m.Called(collectionID, skuID, req)
+	res, _ := args.Get(0).(*model.Inventory)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *InventoryMock) UpdateWithContext(ctx context.Context, collectionID string, skuID string, req model.UpdateInventoryRequest) (*model.Inventory, *common.Error) {
+	args := m.Called(ctx, collectionID, skuID, req)
+	res, _ := args.Get(0).(*model.Inventory)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
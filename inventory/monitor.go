@@ -0,0 +1,178 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+)
+
+const (
+	AlertLowStock   string = "low_stock"
+	AlertOutOfStock string = "out_of_stock"
+	AlertRestocked  string = "restocked"
+)
+
+type StockAlert struct {
+	Kind  string
+	SKUID string
+	// Previous is the last known quantity, nil on the first observation.
+	Previous  *int
+	Quantity  int
+	Threshold int
+}
+
+type AlertFunc func(alert StockAlert)
+
+// Monitor tracks SKU quantities and calls back when a SKU drops to its
+// low stock threshold, runs out of stock or climbs back above the threshold.
+// Callbacks only fire when a quantity crosses a threshold, not on every
+// update below it; a SKU coming back in stock but not above its threshold
+// alerts low stock. SKUs with infinite inventory never alert.
+type Monitor struct {
+	mu         sync.Mutex
+	threshold  int
+	thresholds map[string]int
+	quantities map[string]int
+	callbacks  map[string][]AlertFunc
+}
+
+func NewMonitor(threshold int) *Monitor {
+	return &Monitor{
+		threshold:  threshold,
+		thresholds: map[string]int{},
+		quantities: map[string]int{},
+		callbacks:  map[string][]AlertFunc{},
+	}
+}
+
+// SetThreshold overrides the low stock threshold of a single SKU.
+func (m *Monitor) SetThreshold(skuID string, threshold int) *Monitor {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.thresholds[skuID] = threshold
+	return m
+}
+
+func (m *Monitor) OnLowStock(fn AlertFunc) *Monitor {
+	return m.on(AlertLowStock, fn)
+}
+
+func (m *Monitor) OnOutOfStock(fn AlertFunc) *Monitor {
+	return m.on(AlertOutOfStock, fn)
+}
+
+func (m *Monitor) OnRestocked(fn AlertFunc) *Monitor {
+	return m.on(AlertRestocked, fn)
+}
+
+func (m *Monitor) on(kind string, fn AlertFunc) *Monitor {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.callbacks[kind] = append(m.callbacks[kind], fn)
+	return m
+}
+
+// Observe records the current inventory of a SKU and fires the callbacks
+// for any threshold it crossed.
+func (m *Monitor) Observe(inventory model.Inventory) {
+	m.mu.Lock()
+
+	if inventory.InventoryType == model.InventoryTypeInfinite {
+		delete(m.quantities, inventory.ID)
+		m.mu.Unlock()
+		return
+	}
+
+	threshold, ok := m.thresholds[inventory.ID]
+	if !ok {
+		threshold = m.threshold
+	}
+
+	var previous *int
+	if quantity, ok := m.quantities[inventory.ID]; ok {
+		previous = &quantity
+	}
+	m.quantities[inventory.ID] = inventory.Quantity
+
+	var alerts []StockAlert
+	alert := func(kind string) {
+		alerts = append(alerts, StockAlert{
+			Kind:      kind,
+			SKUID:     inventory.ID,
+			Previous:  previous,
+			Quantity:  inventory.Quantity,
+			Threshold: threshold,
+		})
+	}
+
+	switch {
+	case inventory.Quantity <= 0:
+		if previous == nil || *previous > 0 {
+			alert(AlertOutOfStock)
+		}
+	case inventory.Quantity <= threshold:
+		if previous == nil || *previous > threshold || *previous <= 0 {
+			alert(AlertLowStock)
+		}
+	case previous != nil && *previous <= threshold:
+		alert(AlertRestocked)
+	}
+
+	callbacks := make([][]AlertFunc, len(alerts))
+	for i, a := range alerts {
+		callbacks[i] = m.callbacks[a.Kind]
+	}
+	m.mu.Unlock()
+
+	for i, a := range alerts {
+		for _, fn := range callbacks[i] {
+			fn(a)
+		}
+	}
+}
+
+// Quantity returns the last observed quantity of a SKU.
+func (m *Monitor) Quantity(skuID string) (int, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	quantity, ok := m.quantities[skuID]
+	return quantity, ok
+}
+
+// Refresh observes the current inventory of the given SKUs, e.g. to seed
+// the monitor on startup before webhooks arrive.
+func (m *Monitor) Refresh(ctx context.Context, service Inventory, collectionID string, skuIDs []string) *common.Error {
+	for _, skuID := range skuIDs {
+		current, err := service.GetWithContext(ctx, collectionID, skuID)
+		if err != nil {
+			return err
+		}
+
+		m.Observe(*current)
+	}
+
+	return nil
+}
+
+// HandleWebhook observes ecomm_inventory_changed events. Register it with
+// webhook.Dispatcher.Handle for model.TriggerEcommInventoryChanged.
+func (m *Monitor) HandleWebhook(ctx context.Context, event model.WebhookEvent) error {
+	var changed model.InventoryChanged
+	if err := json.Unmarshal(event.Payload, &changed); err != nil {
+		return err
+	}
+
+	m.Observe(model.Inventory{
+		ID:            changed.ID,
+		Quantity:      changed.Quantity,
+		InventoryType: changed.InventoryType,
+	})
+
+	return nil
+}
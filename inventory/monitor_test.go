@@ -0,0 +1,113 @@
+package inventory_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nasrul21/go-webflow/inventory"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/nasrul21/go-webflow/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonitor(t *testing.T) {
+	var alerts []string
+	record := func(alert inventory.StockAlert) {
+		alerts = append(alerts, alert.Kind+":"+alert.SKUID)
+	}
+
+	monitor := inventory.NewMonitor(5).
+		SetThreshold("sku-2", 10).
+		OnLowStock(record).
+		OnOutOfStock(record).
+		OnRestocked(record)
+
+	observations := []struct {
+		desc           string
+		inventory      model.Inventory
+		expectedAlerts []string
+	}{
+		{
+			desc:      "should not alert above threshold",
+			inventory: model.Inventory{ID: "sku-1", Quantity: 20, InventoryType: model.InventoryTypeFinite},
+		},
+		{
+			desc:           "should alert low stock when crossing threshold",
+			inventory:      model.Inventory{ID: "sku-1", Quantity: 5, InventoryType: model.InventoryTypeFinite},
+			expectedAlerts: []string{"low_stock:sku-1"},
+		},
+		{
+			desc:      "should not alert again while below threshold",
+			inventory: model.Inventory{ID: "sku-1", Quantity: 3, InventoryType: model.InventoryTypeFinite},
+		},
+		{
+			desc:           "should alert out of stock",
+			inventory:      model.Inventory{ID: "sku-1", Quantity: 0, InventoryType: model.InventoryTypeFinite},
+			expectedAlerts: []string{"out_of_stock:sku-1"},
+		},
+		{
+			desc:           "should alert low stock when back in stock below threshold",
+			inventory:      model.Inventory{ID: "sku-1", Quantity: 2, InventoryType: model.InventoryTypeFinite},
+			expectedAlerts: []string{"low_stock:sku-1"},
+		},
+		{
+			desc:           "should alert restocked",
+			inventory:      model.Inventory{ID: "sku-1", Quantity: 50, InventoryType: model.InventoryTypeFinite},
+			expectedAlerts: []string{"restocked:sku-1"},
+		},
+		{
+			desc:           "should use sku threshold on first observation",
+			inventory:      model.Inventory{ID: "sku-2", Quantity: 8, InventoryType: model.InventoryTypeFinite},
+			expectedAlerts: []string{"low_stock:sku-2"},
+		},
+		{
+			desc:      "should ignore infinite inventory",
+			inventory: model.Inventory{ID: "sku-3", InventoryType: model.InventoryTypeInfinite},
+		},
+	}
+
+	for _, tc := range observations {
+		t.Run(tc.desc, func(t *testing.T) {
+			alerts = nil
+
+			monitor.Observe(tc.inventory)
+
+			assert.Equal(t, tc.expectedAlerts, alerts)
+		})
+	}
+}
+
+func TestMonitorWebhook(t *testing.T) {
+	var alert inventory.StockAlert
+	monitor := inventory.NewMonitor(2).OnOutOfStock(func(a inventory.StockAlert) {
+		alert = a
+	})
+
+	service := &fakeInventory{
+		stock: map[string]model.Inventory{
+			skuID: {ID: skuID, Quantity: 4, InventoryType: model.InventoryTypeFinite},
+		},
+	}
+	assert.Nil(t, monitor.Refresh(context.Background(), service, collectionID, []string{skuID}))
+
+	dispatcher := webhook.NewDispatcher().Handle(model.TriggerEcommInventoryChanged, monitor.HandleWebhook)
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/webhooks?triggerType=ecomm_inventory_changed",
+		strings.NewReader(`{"_id": "5eb9fd05caef491eb9757187", "quantity": 0, "inventoryType": "finite"}`),
+	)
+	rec := httptest.NewRecorder()
+	webhook.NewReceiver(dispatcher).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, inventory.AlertOutOfStock, alert.Kind)
+	assert.Equal(t, skuID, alert.SKUID)
+	assert.Equal(t, 4, *alert.Previous)
+
+	quantity, ok := monitor.Quantity(skuID)
+	assert.True(t, ok)
+	assert.Equal(t, 0, quantity)
+}
@@ -0,0 +1,116 @@
+package inventory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+)
+
+const (
+	SyncUnchanged string = "unchanged"
+	SyncUpdated   string = "updated"
+	SyncFailed    string = "failed"
+)
+
+type SyncOptions struct {
+	// Concurrency is the number of SKUs synced in parallel, 4 when zero.
+	Concurrency int
+	// Retry paces and retries every call. Share its Limiter with other
+	// callers of the same token to stay within the rate limit. Without a
+	// Limiter calls are paced to Webflow's 60 requests per minute.
+	Retry common.RetryPolicy
+}
+
+type SyncResult struct {
+	SKUID    string
+	Status   string
+	Previous *model.Inventory
+	Current  *model.Inventory
+	Err      *common.Error
+}
+
+// Sync sets the finite inventory of every SKU in quantities, keyed by SKU
+// ID, updating only SKUs whose current inventory differs. Results are
+// returned for every SKU, sorted by SKU ID.
+func Sync(ctx context.Context, service Inventory, collectionID string, quantities map[string]int, opts SyncOptions) []SyncResult {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	retry := opts.Retry
+	if retry.Limiter == nil {
+		retry.Limiter = common.NewLimiter(60)
+	}
+
+	skuIDs := make([]string, 0, len(quantities))
+	for skuID := range quantities {
+		skuIDs = append(skuIDs, skuID)
+	}
+	sort.Strings(skuIDs)
+
+	results := make([]SyncResult, len(skuIDs))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = syncOne(ctx, service, collectionID, skuIDs[i], quantities[skuIDs[i]], retry)
+			}
+		}()
+	}
+
+	for i := range skuIDs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+func syncOne(ctx context.Context, service Inventory, collectionID string, skuID string, quantity int, retry common.RetryPolicy) SyncResult {
+	result := SyncResult{SKUID: skuID}
+
+	if err := ctx.Err(); err != nil {
+		result.Status = SyncFailed
+		result.Err = common.FromGoErr(err)
+		return result
+	}
+
+	err := retry.Do(ctx, func() *common.Error {
+		var err *common.Error
+		result.Previous, err = service.GetWithContext(ctx, collectionID, skuID)
+		return err
+	})
+	if err != nil {
+		result.Status = SyncFailed
+		result.Err = err
+		return result
+	}
+
+	if result.Previous.InventoryType == model.InventoryTypeFinite && result.Previous.Quantity == quantity {
+		result.Status = SyncUnchanged
+		result.Current = result.Previous
+		return result
+	}
+
+	err = retry.Do(ctx, func() *common.Error {
+		var err *common.Error
+		result.Current, err = service.UpdateWithContext(ctx, collectionID, skuID, model.SetInventoryQuantity(quantity))
+		return err
+	})
+	if err != nil {
+		result.Status = SyncFailed
+		result.Err = err
+		return result
+	}
+
+	result.Status = SyncUpdated
+	return result
+}
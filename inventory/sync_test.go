@@ -0,0 +1,119 @@
+package inventory_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/inventory"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeInventory struct {
+	inventory.Inventory
+	mu          sync.Mutex
+	stock       map[string]model.Inventory
+	updated     []string
+	rateLimited int
+}
+
+func (f *fakeInventory) GetWithContext(ctx context.Context, collectionID string, skuID string) (*model.Inventory, *common.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.rateLimited > 0 {
+		f.rateLimited--
+		return nil, &common.Error{Code: http.StatusTooManyRequests}
+	}
+
+	current, ok := f.stock[skuID]
+	if !ok {
+		return nil, &common.Error{Code: http.StatusNotFound, Err: "Item not found"}
+	}
+
+	return &current, nil
+}
+
+func (f *fakeInventory) UpdateWithContext(ctx context.Context, collectionID string, skuID string, req model.UpdateInventoryRequest) (*model.Inventory, *common.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	updated := model.Inventory{ID: skuID, Quantity: *req.Quantity, InventoryType: req.InventoryType}
+	f.stock[skuID] = updated
+	f.updated = append(f.updated, skuID)
+
+	return &updated, nil
+}
+
+func TestSync(t *testing.T) {
+	service := &fakeInventory{
+		stock: map[string]model.Inventory{
+			"sku-1": {ID: "sku-1", Quantity: 5, InventoryType: model.InventoryTypeFinite},
+			"sku-2": {ID: "sku-2", Quantity: 3, InventoryType: model.InventoryTypeFinite},
+			"sku-3": {ID: "sku-3", InventoryType: model.InventoryTypeInfinite},
+		},
+		rateLimited: 1,
+	}
+
+	results := inventory.Sync(context.Background(), service, collectionID, map[string]int{
+		"sku-1": 5,
+		"sku-2": 7,
+		"sku-3": 0,
+		"sku-4": 1,
+	}, inventory.SyncOptions{
+		Concurrency: 2,
+		Retry:       common.RetryPolicy{Limiter: common.NewLimiter(60000), Wait: time.Millisecond},
+	})
+
+	statuses := map[string]string{}
+	for _, result := range results {
+		statuses[result.SKUID] = result.Status
+	}
+
+	assert.Equal(t, []string{"sku-1", "sku-2", "sku-3", "sku-4"}, []string{results[0].SKUID, results[1].SKUID, results[2].SKUID, results[3].SKUID})
+	assert.Equal(t, map[string]string{
+		"sku-1": inventory.SyncUnchanged,
+		"sku-2": inventory.SyncUpdated,
+		"sku-3": inventory.SyncUpdated,
+		"sku-4": inventory.SyncFailed,
+	}, statuses)
+	assert.Equal(t, 3, results[1].Previous.Quantity)
+	assert.Equal(t, 7, results[1].Current.Quantity)
+	assert.Equal(t, model.InventoryTypeFinite, service.stock["sku-3"].InventoryType)
+	assert.Equal(t, http.StatusNotFound, results[3].Err.Code)
+	assert.ElementsMatch(t, []string{"sku-2", "sku-3"}, service.updated)
+}
+
+func TestSyncDefaultLimiter(t *testing.T) {
+	service := &fakeInventory{
+		stock: map[string]model.Inventory{
+			"sku-1": {ID: "sku-1", Quantity: 5, InventoryType: model.InventoryTypeFinite},
+			"sku-2": {ID: "sku-2", Quantity: 3, InventoryType: model.InventoryTypeFinite},
+		},
+	}
+
+	start := time.Now()
+	results := inventory.Sync(context.Background(), service, collectionID, map[string]int{
+		"sku-1": 5,
+		"sku-2": 3,
+	}, inventory.SyncOptions{Concurrency: 2})
+
+	assert.Equal(t, inventory.SyncUnchanged, results[0].Status)
+	assert.Equal(t, inventory.SyncUnchanged, results[1].Status)
+	assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+}
+
+func TestSyncCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := inventory.Sync(ctx, &fakeInventory{}, collectionID, map[string]int{"sku-1": 1}, inventory.SyncOptions{})
+
+	assert.Equal(t, inventory.SyncFailed, results[0].Status)
+	assert.Equal(t, common.FromGoErr(fmt.Errorf("context canceled")), results[0].Err)
+}
@@ -0,0 +1,351 @@
+package item
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+)
+
+const (
+	FormatCSV    string = "csv"
+	FormatJSON   string = "json"
+	FormatNDJSON string = "ndjson"
+)
+
+const (
+	// ReferencesID exports referenced items by ID.
+	ReferencesID string = "id"
+	// ReferencesSlug exports referenced items by slug, which costs listing
+	// every referenced collection once.
+	ReferencesSlug string = "slug"
+)
+
+// maxPageSize is the largest page the API returns.
+const maxPageSize = 100
+
+// ForEach calls fn for every item of a collection, fetching pages of
+// params.Limit items (100 when zero or above 100, the API maximum) until the
+// list is exhausted. It stops at the first error returned by fn.
+func ForEach(ctx context.Context, service Item, collectionID string, params model.PaginationParams, fn func(item model.Item) error) *common.Error {
+	if params.Limit <= 0 || params.Limit > maxPageSize {
+		params.Limit = maxPageSize
+	}
+
+	for {
+		list, err := service.GetListWithContext(ctx, collectionID, params)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range list.Items {
+			if err := fn(item); err != nil {
+				return common.FromGoErr(err)
+			}
+		}
+
+		params.Offset += len(list.Items)
+		if len(list.Items) < params.Limit || params.Offset >= list.Total {
+			return nil
+		}
+	}
+}
+
+type ExportOptions struct {
+	// Format is FormatCSV, FormatJSON or FormatNDJSON, FormatCSV when empty.
+	Format string
+	// References is ReferencesID or ReferencesSlug, ReferencesID when empty.
+	References string
+	PageSize   int
+}
+
+// ExportColumns returns the CSV header of a collection: the item ID, the
+// schema fields in order and the item state and timestamps.
+func ExportColumns(collection model.Collection) []string {
+	columns := []string{"_id"}
+	for _, slug := range []string{"name", "slug"} {
+		if collection.Field(slug) == nil {
+			columns = append(columns, slug)
+		}
+	}
+	for _, field := range collection.Fields {
+		columns = append(columns, field.Slug)
+	}
+
+	return append(columns, "_archived", "_draft", "created-on", "updated-on", "published-on")
+}
+
+// Flatten returns the values of an item by column, simplified with the
+// collection schema: images and videos become their URL, options their
+// name and references the result of ref, which is given the referenced
+// collection and item IDs. Rich text stays HTML. Multiple values are
+// slices of strings.
+func Flatten(collection model.Collection, item model.Item, ref func(collectionID string, itemID string) string) map[string]interface{} {
+	values := map[string]interface{}{
+		"_id":          item.ID,
+		"name":         item.Name,
+		"slug":         item.Slug,
+		"_archived":    item.Archived,
+		"_draft":       item.Draft,
+		"created-on":   formatTime(item.CreatedOn),
+		"updated-on":   formatTime(item.UpdatedOn),
+		"published-on": formatTime(item.PublishedOn),
+	}
+
+	for _, field := range collection.Fields {
+		if _, ok := values[field.Slug]; ok {
+			continue
+		}
+		values[field.Slug] = flattenValue(field, item.Fields[field.Slug], ref)
+	}
+
+	return values
+}
+
+func flattenValue(field model.CollectionField, value interface{}, ref func(collectionID string, itemID string) string) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	switch field.Type {
+	case model.FieldTypeImage, model.FieldTypeVideo:
+		return fileURL(value)
+	case model.FieldTypeImageSet:
+		values, _ := value.([]interface{})
+		urls := make([]string, 0, len(values))
+		for _, v := range values {
+			urls = append(urls, fileURL(v))
+		}
+		return urls
+	case model.FieldTypeOption:
+		id, _ := value.(string)
+		return optionName(field, id)
+	case model.FieldTypeItemRef:
+		id, _ := value.(string)
+		return ref(ReferencedCollection(field), id)
+	case model.FieldTypeItemRefSet:
+		values, _ := value.([]interface{})
+		refs := make([]string, 0, len(values))
+		for _, v := range values {
+			id, _ := v.(string)
+			refs = append(refs, ref(ReferencedCollection(field), id))
+		}
+		return refs
+	}
+
+	return value
+}
+
+// fileURL returns the URL of an image or video value, which the API returns
+// as an object.
+func fileURL(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		url, _ := v["url"].(string)
+		return url
+	}
+
+	return ""
+}
+
+func optionName(field model.CollectionField, id string) string {
+	options, _ := field.Validations["options"].([]interface{})
+	for _, option := range options {
+		o, _ := option.(map[string]interface{})
+		if o["id"] == id {
+			if name, ok := o["name"].(string); ok {
+				return name
+			}
+		}
+	}
+
+	return id
+}
+
+// ReferencedCollection returns the ID of the collection a reference field
+// points to.
+func ReferencedCollection(field model.CollectionField) string {
+	id, _ := field.Validations["collectionId"].(string)
+	return id
+}
+
+// CSVValue formats a flattened value for a CSV cell. Multiple values are
+// joined with commas.
+func CSVValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []string:
+		return strings.Join(v, ",")
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(data)
+}
+
+// Export writes every item of a collection to w and returns the number of
+// items exported. The collection must hold its schema, as returned by
+// Collection.Get.
+func Export(ctx context.Context, service Item, collection model.Collection, w io.Writer, opts ExportOptions) (int, *common.Error) {
+	slugs := &slugCache{ctx: ctx, service: service, pageSize: opts.PageSize}
+	var ref func(collectionID string, itemID string) string
+	switch opts.References {
+	case "", ReferencesID:
+		ref = func(collectionID string, itemID string) string {
+			return itemID
+		}
+	case ReferencesSlug:
+		ref = slugs.slug
+	default:
+		return 0, common.FromGoErr(fmt.Errorf("item: unknown references %q", opts.References))
+	}
+
+	var write func(values map[string]interface{}) error
+	var flush func() error
+
+	switch opts.Format {
+	case "", FormatCSV:
+		columns := ExportColumns(collection)
+		writer := csv.NewWriter(w)
+		if err := writer.Write(columns); err != nil {
+			return 0, common.FromGoErr(err)
+		}
+		write = func(values map[string]interface{}) error {
+			record := make([]string, len(columns))
+			for i, column := range columns {
+				record[i] = CSVValue(values[column])
+			}
+			return writer.Write(record)
+		}
+		flush = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	case FormatJSON:
+		first := true
+		write = func(values map[string]interface{}) error {
+			data, err := json.Marshal(values)
+			if err != nil {
+				return err
+			}
+			sep := ",\n  "
+			if first {
+				sep = "[\n  "
+				first = false
+			}
+			_, err = fmt.Fprintf(w, "%s%s", sep, data)
+			return err
+		}
+		flush = func() error {
+			end := "\n]\n"
+			if first {
+				end = "[]\n"
+			}
+			_, err := io.WriteString(w, end)
+			return err
+		}
+	case FormatNDJSON:
+		encoder := json.NewEncoder(w)
+		write = func(values map[string]interface{}) error {
+			return encoder.Encode(values)
+		}
+		flush = func() error {
+			return nil
+		}
+	default:
+		return 0, common.FromGoErr(fmt.Errorf("item: unknown export format %q", opts.Format))
+	}
+
+	count := 0
+	err := ForEach(ctx, service, collection.ID, model.PaginationParams{Limit: opts.PageSize}, func(item model.Item) error {
+		values := Flatten(collection, item, ref)
+		if slugs.err != nil {
+			return errStopped
+		}
+		if err := write(values); err != nil {
+			return err
+		}
+		count++
+
+		return nil
+	})
+	if slugs.err != nil {
+		return count, slugs.err
+	}
+	if err != nil {
+		return count, err
+	}
+
+	if err := flush(); err != nil {
+		return count, common.FromGoErr(err)
+	}
+
+	return count, nil
+}
+
+// errStopped ends a ForEach whose real error is kept elsewhere.
+var errStopped = errors.New("item: stopped")
+
+// slugCache lists a referenced collection the first time one of its items
+// is looked up. References to items that no longer exist keep their ID.
+type slugCache struct {
+	ctx      context.Context
+	service  Item
+	pageSize int
+	slugs    map[string]map[string]string
+	// err is the error listing a collection failed with.
+	err *common.Error
+}
+
+func (c *slugCache) slug(collectionID string, itemID string) string {
+	if c.slugs == nil {
+		c.slugs = map[string]map[string]string{}
+	}
+
+	slugs, ok := c.slugs[collectionID]
+	if !ok && collectionID != "" {
+		slugs = map[string]string{}
+		err := ForEach(c.ctx, c.service, collectionID, model.PaginationParams{Limit: c.pageSize}, func(item model.Item) error {
+			slugs[item.ID] = item.Slug
+			return nil
+		})
+		if err != nil && c.err == nil {
+			c.err = err
+		}
+		c.slugs[collectionID] = slugs
+	}
+
+	if slug, ok := slugs[itemID]; ok {
+		return slug
+	}
+
+	return itemID
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,192 @@
+package item_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/item"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/stretchr/testify/assert"
+)
+
+const authorsID = "580e63fc8c9a982ac9b8b746"
+
+type fakeItemList struct {
+	item.Item
+	items  map[string][]model.Item
+	calls  []string
+	writes []write
+}
+
+func (f *fakeItemList) GetListWithContext(ctx context.Context, collectionID string, params model.PaginationParams) (*model.ItemList, *common.Error) {
+	f.calls = append(f.calls, collectionID)
+
+	items, ok := f.items[collectionID]
+	if !ok {
+		return nil, &common.Error{Code: http.StatusNotFound, Message: "Requested resource not found", Name: "NotFound"}
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+	start, end := params.Offset, params.Offset+params.Limit
+	if start > len(items) {
+		start = len(items)
+	}
+	if end > len(items) {
+		end = len(items)
+	}
+
+	return &model.ItemList{Items: items[start:end], Count: end - start, Limit: params.Limit, Offset: params.Offset, Total: len(items)}, nil
+}
+
+func exportCollection() model.Collection {
+	return model.Collection{
+		ID:   collectionID,
+		Name: "Blog Posts",
+		Slug: "post",
+		Fields: []model.CollectionField{
+			{Slug: "name", Type: model.FieldTypePlainText},
+			{Slug: "slug", Type: model.FieldTypePlainText},
+			{Slug: "post-body", Type: model.FieldTypeRichText},
+			{Slug: "main-image", Type: model.FieldTypeImage},
+			{Slug: "gallery", Type: model.FieldTypeImageSet},
+			{Slug: "reading-time", Type: model.FieldTypeNumber},
+			{Slug: "featured", Type: model.FieldTypeBool},
+			{Slug: "category", Type: model.FieldTypeOption, Validations: map[string]interface{}{
+				"options": []interface{}{map[string]interface{}{"id": "a1", "name": "News"}},
+			}},
+			{Slug: "author", Type: model.FieldTypeItemRef, Validations: map[string]interface{}{"collectionId": authorsID}},
+			{Slug: "co-authors", Type: model.FieldTypeItemRefSet, Validations: map[string]interface{}{"collectionId": authorsID}},
+		},
+	}
+}
+
+func exportItems() map[string][]model.Item {
+	return map[string][]model.Item{
+		collectionID: {
+			{
+				ID:   "580e640c8c9a982ac9b8b77a",
+				Name: "Hello World",
+				Slug: "hello-world",
+				Fields: map[string]interface{}{
+					"post-body":    "<p>Hello, <strong>world</strong></p>",
+					"main-image":   map[string]interface{}{"fileId": "f1", "url": "https://uploads.webflow.com/f1.png"},
+					"gallery":      []interface{}{map[string]interface{}{"fileId": "f2", "url": "https://uploads.webflow.com/f2.png"}, map[string]interface{}{"fileId": "f3", "url": "https://uploads.webflow.com/f3.png"}},
+					"reading-time": float64(3),
+					"featured":     true,
+					"category":     "a1",
+					"author":       "a-1",
+					"co-authors":   []interface{}{"a-2", "a-gone"},
+				},
+			},
+			{ID: "580e640c8c9a982ac9b8b77b", Name: "Draft", Slug: "draft", Draft: true, Fields: map[string]interface{}{}},
+			{ID: "580e640c8c9a982ac9b8b77c", Name: "Third", Slug: "third", Fields: map[string]interface{}{}},
+		},
+		authorsID: {
+			{ID: "a-1", Slug: "arthur-dent"},
+			{ID: "a-2", Slug: "ford-prefect"},
+		},
+	}
+}
+
+func TestForEach(t *testing.T) {
+	items := make([]model.Item, 150)
+	for i := range items {
+		items[i].ID = strconv.Itoa(i)
+	}
+	service := &fakeItemList{items: map[string][]model.Item{collectionID: items}}
+
+	count := 0
+	err := item.ForEach(context.Background(), service, collectionID, model.PaginationParams{Limit: 250}, func(model.Item) error {
+		count++
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 150, count)
+	assert.Len(t, service.calls, 2)
+}
+
+func TestExportCSV(t *testing.T) {
+	fake := &fakeItemList{items: exportItems()}
+
+	var buf bytes.Buffer
+	count, err := item.Export(context.Background(), fake, exportCollection(), &buf, item.ExportOptions{References: item.ReferencesSlug, PageSize: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, []string{collectionID, authorsID, collectionID}, fake.calls)
+
+	records, _ := csv.NewReader(&buf).ReadAll()
+	assert.Len(t, records, 4)
+	assert.Equal(t, []string{
+		"_id", "name", "slug", "post-body", "main-image", "gallery", "reading-time", "featured", "category", "author", "co-authors",
+		"_archived", "_draft", "created-on", "updated-on", "published-on",
+	}, records[0])
+	assert.Equal(t, []string{
+		"580e640c8c9a982ac9b8b77a", "Hello World", "hello-world", "<p>Hello, <strong>world</strong></p>",
+		"https://uploads.webflow.com/f1.png", "https://uploads.webflow.com/f2.png,https://uploads.webflow.com/f3.png",
+		"3", "true", "News", "arthur-dent", "ford-prefect,a-gone",
+		"false", "false", "", "", "",
+	}, records[1])
+	assert.Equal(t, "true", records[2][12])
+}
+
+func TestExportJSON(t *testing.T) {
+	fake := &fakeItemList{items: exportItems()}
+
+	var buf bytes.Buffer
+	count, err := item.Export(context.Background(), fake, exportCollection(), &buf, item.ExportOptions{Format: item.FormatJSON})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, []string{collectionID}, fake.calls)
+
+	var items []map[string]interface{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &items))
+	assert.Len(t, items, 3)
+	assert.Equal(t, "a-1", items[0]["author"])
+	assert.Equal(t, []interface{}{"a-2", "a-gone"}, items[0]["co-authors"])
+	assert.Equal(t, float64(3), items[0]["reading-time"])
+	assert.Nil(t, items[1]["author"])
+
+	buf.Reset()
+	fake.items[collectionID] = nil
+	_, err = item.Export(context.Background(), fake, exportCollection(), &buf, item.ExportOptions{Format: item.FormatJSON})
+	assert.Nil(t, err)
+	assert.Equal(t, "[]\n", buf.String())
+}
+
+func TestExportNDJSON(t *testing.T) {
+	fake := &fakeItemList{items: exportItems()}
+
+	var buf bytes.Buffer
+	_, err := item.Export(context.Background(), fake, exportCollection(), &buf, item.ExportOptions{Format: item.FormatNDJSON})
+	assert.Nil(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 3)
+
+	var first map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, "https://uploads.webflow.com/f1.png", first["main-image"])
+}
+
+func TestExportErrors(t *testing.T) {
+	items := exportItems()
+	delete(items, authorsID)
+	fake := &fakeItemList{items: items}
+
+	var buf bytes.Buffer
+	count, err := item.Export(context.Background(), fake, exportCollection(), &buf, item.ExportOptions{References: item.ReferencesSlug})
+	assert.Equal(t, 0, count)
+	assert.Equal(t, http.StatusNotFound, err.Code)
+
+	_, err = item.Export(context.Background(), fake, exportCollection(), &buf, item.ExportOptions{Format: "xml"})
+	assert.Equal(t, common.GoErrCode, err.Err)
+}
@@ -0,0 +1,453 @@
+package item
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+)
+
+const (
+	ImportCreated string = "created"
+	ImportUpdated string = "updated"
+	ImportFailed  string = "failed"
+)
+
+// Mapping configures how the columns of a CSV become item fields. It is
+// usually read from a JSON file with LoadMapping:
+//
+//	{
+//	  "columns": [
+//	    {"column": "Title", "field": "name"},
+//	    {"column": "Published", "field": "published-on", "layout": "02/01/2006"},
+//	    {"column": "Tags", "field": "tags", "separator": ";"}
+//	  ]
+//	}
+type Mapping struct {
+	// Columns lists the imported columns. When empty, every column whose
+	// header is a field slug, _archived or _draft is imported, so that an
+	// export can be imported back.
+	Columns []ColumnMapping `json:"columns"`
+}
+
+// ColumnMapping imports one CSV column into an item field. Values are
+// coerced to the type of the field in the collection schema:
+//
+//   - Number and Bool cells are parsed, booleans from true, false, yes, no,
+//     1 or 0.
+//   - Date cells are parsed with Layout, RFC 3339 or 2006-01-02 when empty.
+//   - Option cells hold the option name or ID.
+//   - ImageRef and Video cells hold a URL; Set cells hold URLs.
+//   - ItemRef cells hold the slug or ID of the referenced item and
+//     ItemRefSet cells several of them.
+//
+// Empty cells leave the field unset.
+type ColumnMapping struct {
+	Column string `json:"column"`
+	Field  string `json:"field"`
+	// Layout is the time layout of Date cells.
+	Layout string `json:"layout,omitempty"`
+	// Separator splits the values of Set and ItemRefSet cells, "," when
+	// empty.
+	Separator string `json:"separator,omitempty"`
+	// Lookup is ReferencesSlug or ReferencesID for reference fields.
+	// ReferencesSlug, the default, resolves slugs to IDs and checks IDs
+	// exist; ReferencesID sends the values as they are.
+	Lookup string `json:"lookup,omitempty"`
+}
+
+// LoadMapping reads a mapping from a JSON file.
+func LoadMapping(path string) (*Mapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var mapping Mapping
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("item: read mapping %s: %w", path, err)
+	}
+
+	return &mapping, nil
+}
+
+type ImportOptions struct {
+	Mapping Mapping
+	// DryRun validates every row and reports what would be created or
+	// updated without writing anything.
+	DryRun bool
+	// Live publishes the created and updated items at once.
+	Live     bool
+	PageSize int
+}
+
+// ImportResult is the outcome of one CSV row. Line is the line number in
+// the CSV, the header being line 1. In a dry run Status tells what would
+// have been done and ItemID is empty for items that would be created.
+type ImportResult struct {
+	Line   int
+	Slug   string
+	Status string
+	ItemID string
+	Err    *common.Error
+}
+
+// reservedFields are the fields every item has besides the schema.
+var reservedFields = map[string]string{
+	"_archived": model.FieldTypeBool,
+	"_draft":    model.FieldTypeBool,
+}
+
+type importer struct {
+	ctx        context.Context
+	service    Item
+	collection model.Collection
+	opts       ImportOptions
+	columns    []ColumnMapping
+	header     map[string]int
+	// existing are the items of the collection by slug, including the ones
+	// created by earlier rows.
+	existing map[string]*model.Item
+	// refs are the IDs of the items of referenced collections by slug and
+	// by ID.
+	refs map[string]map[string]string
+}
+
+// Import creates or updates the items of a collection from a CSV. Items are
+// matched by slug; rows without a slug get one made from their name. Rows
+// of existing items only change the mapped fields. The collection must hold
+// its schema, as returned by Collection.Get.
+//
+// It returns one result per data row; the error is only set when the CSV,
+// the mapping or the existing items cannot be read.
+func Import(ctx context.Context, service Item, collection model.Collection, r io.Reader, opts ImportOptions) ([]ImportResult, *common.Error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, common.FromGoErr(fmt.Errorf("item: read header: %w", err))
+	}
+
+	im := &importer{
+		ctx:        ctx,
+		service:    service,
+		collection: collection,
+		opts:       opts,
+		header:     map[string]int{},
+		existing:   map[string]*model.Item{},
+		refs:       map[string]map[string]string{},
+	}
+	for i, name := range header {
+		header[i] = strings.TrimSpace(name)
+		im.header[header[i]] = i
+	}
+	if err := im.mapColumns(header); err != nil {
+		return nil, common.FromGoErr(err)
+	}
+
+	listErr := ForEach(ctx, service, collection.ID, model.PaginationParams{Limit: opts.PageSize}, func(item model.Item) error {
+		im.existing[item.Slug] = &item
+		return nil
+	})
+	if listErr != nil {
+		return nil, listErr
+	}
+
+	var results []ImportResult
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return results, common.FromGoErr(fmt.Errorf("item: read line %d: %w", line, err))
+		}
+
+		result, refErr := im.importRow(line, record)
+		if refErr != nil {
+			return results, refErr
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// mapColumns checks the mapping against the header and the schema.
+func (im *importer) mapColumns(header []string) error {
+	im.columns = im.opts.Mapping.Columns
+	if len(im.columns) == 0 {
+		for _, name := range header {
+			if _, ok := im.fieldType(name); ok {
+				im.columns = append(im.columns, ColumnMapping{Column: name, Field: name})
+			}
+		}
+	}
+
+	var problems []string
+	for _, column := range im.columns {
+		if _, ok := im.header[column.Column]; !ok {
+			problems = append(problems, fmt.Sprintf("column %q not in CSV", column.Column))
+		}
+		if _, ok := im.fieldType(column.Field); !ok {
+			problems = append(problems, fmt.Sprintf("field %q not in collection %s", column.Field, im.collection.Slug))
+		}
+		if column.Lookup != "" && column.Lookup != ReferencesSlug && column.Lookup != ReferencesID {
+			problems = append(problems, fmt.Sprintf("unknown lookup %q of column %q", column.Lookup, column.Column))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("item: invalid mapping: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+func (im *importer) fieldType(slug string) (string, bool) {
+	if fieldType, ok := reservedFields[slug]; ok {
+		return fieldType, true
+	}
+	if slug == "name" || slug == "slug" {
+		return model.FieldTypePlainText, true
+	}
+	if field := im.collection.Field(slug); field != nil {
+		return field.Type, true
+	}
+
+	return "", false
+}
+
+// importRow returns the result of a row. The error is set when a referenced
+// collection cannot be read, which fails every later row as well.
+func (im *importer) importRow(line int, record []string) (ImportResult, *common.Error) {
+	fields := map[string]interface{}{}
+	var problems []interface{}
+	for _, column := range im.columns {
+		value := ""
+		if i := im.header[column.Column]; i < len(record) {
+			value = strings.TrimSpace(record[i])
+		}
+		if value == "" {
+			continue
+		}
+
+		coerced, problem, err := im.coerce(column, value)
+		if err != nil {
+			return ImportResult{}, err
+		}
+		if problem != "" {
+			problems = append(problems, fmt.Sprintf("Field '%s': %s", column.Field, problem))
+			continue
+		}
+		fields[column.Field] = coerced
+	}
+
+	slug, _ := fields["slug"].(string)
+	if slug == "" {
+		name, _ := fields["name"].(string)
+		slug = model.Slugify(name)
+		fields["slug"] = slug
+	}
+	result := ImportResult{Line: line, Slug: slug}
+
+	current := im.existing[slug]
+	if current == nil {
+		problems = append(problems, im.missing(fields)...)
+	}
+	if slug == "" {
+		problems = append(problems, "Field 'slug': Field is required")
+	}
+	if len(problems) > 0 {
+		result.Status = ImportFailed
+		result.Err = &common.Error{
+			Code:     http.StatusBadRequest,
+			Message:  "Validation Failure",
+			Err:      common.APIValidationError,
+			Name:     "ValidationError",
+			Problems: problems,
+		}
+		return result, nil
+	}
+
+	req := model.ItemRequest{Fields: fields, Live: im.opts.Live}
+	if current != nil {
+		result.Status = ImportUpdated
+		result.ItemID = current.ID
+		if !im.opts.DryRun {
+			_, result.Err = im.service.PatchWithContext(im.ctx, im.collection.ID, current.ID, req)
+		}
+	} else {
+		result.Status = ImportCreated
+		for _, flag := range []string{"_archived", "_draft"} {
+			if _, ok := fields[flag]; !ok {
+				fields[flag] = false
+			}
+		}
+
+		created := &model.Item{Slug: slug}
+		if !im.opts.DryRun {
+			created, result.Err = im.service.CreateWithContext(im.ctx, im.collection.ID, req)
+		}
+		if result.Err == nil {
+			result.ItemID = created.ID
+			im.existing[slug] = created
+		}
+	}
+	if result.Err != nil {
+		result.Status = ImportFailed
+	}
+
+	return result, nil
+}
+
+// missing returns the problems of the required fields a new item lacks.
+func (im *importer) missing(fields map[string]interface{}) []interface{} {
+	var problems []interface{}
+	if _, ok := fields["name"]; !ok {
+		problems = append(problems, "Field 'name': Field is required")
+	}
+	for _, field := range im.collection.Fields {
+		if !field.Required || field.Slug == "name" || field.Slug == "slug" {
+			continue
+		}
+		if _, ok := fields[field.Slug]; !ok {
+			problems = append(problems, fmt.Sprintf("Field '%s': Field is required", field.Slug))
+		}
+	}
+
+	return problems
+}
+
+// coerce converts a cell to the type of its field. It returns a problem for
+// values of the wrong type and an error when a referenced collection cannot
+// be read.
+func (im *importer) coerce(column ColumnMapping, value string) (interface{}, string, *common.Error) {
+	fieldType, _ := im.fieldType(column.Field)
+	separator := column.Separator
+	if separator == "" {
+		separator = ","
+	}
+
+	switch fieldType {
+	case model.FieldTypeNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Sprintf("Expected a number, got '%s'", value), nil
+		}
+		return n, "", nil
+	case model.FieldTypeBool:
+		switch strings.ToLower(value) {
+		case "true", "yes", "y", "1":
+			return true, "", nil
+		case "false", "no", "n", "0":
+			return false, "", nil
+		}
+		return nil, fmt.Sprintf("Expected a boolean, got '%s'", value), nil
+	case model.FieldTypeDate:
+		layouts := []string{time.RFC3339, "2006-01-02"}
+		if column.Layout != "" {
+			layouts = []string{column.Layout}
+		}
+		for _, layout := range layouts {
+			if t, err := time.Parse(layout, value); err == nil {
+				return t.UTC().Format(time.RFC3339), "", nil
+			}
+		}
+		return nil, fmt.Sprintf("Expected a date, got '%s'", value), nil
+	case model.FieldTypeOption:
+		field := im.collection.Field(column.Field)
+		id, ok := optionID(*field, value)
+		if !ok {
+			return nil, fmt.Sprintf("Unknown option '%s'", value), nil
+		}
+		return id, "", nil
+	case model.FieldTypeImage, model.FieldTypeVideo:
+		return map[string]interface{}{"url": value}, "", nil
+	case model.FieldTypeImageSet:
+		var files []interface{}
+		for _, url := range splitValues(value, separator) {
+			files = append(files, map[string]interface{}{"url": url})
+		}
+		return files, "", nil
+	case model.FieldTypeItemRef:
+		id, problem, err := im.reference(column, value)
+		if problem != "" || err != nil {
+			return nil, problem, err
+		}
+		return id, "", nil
+	case model.FieldTypeItemRefSet:
+		ids := []interface{}{}
+		for _, v := range splitValues(value, separator) {
+			id, problem, err := im.reference(column, v)
+			if problem != "" || err != nil {
+				return nil, problem, err
+			}
+			ids = append(ids, id)
+		}
+		return ids, "", nil
+	}
+
+	return value, "", nil
+}
+
+func optionID(field model.CollectionField, value string) (string, bool) {
+	options, _ := field.Validations["options"].([]interface{})
+	for _, option := range options {
+		o, _ := option.(map[string]interface{})
+		id, _ := o["id"].(string)
+		name, _ := o["name"].(string)
+		if id == value || strings.EqualFold(name, value) {
+			return id, true
+		}
+	}
+
+	return "", false
+}
+
+func splitValues(value string, separator string) []string {
+	var values []string
+	for _, v := range strings.Split(value, separator) {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+// reference resolves the slug or ID of a referenced item to its ID.
+func (im *importer) reference(column ColumnMapping, value string) (string, string, *common.Error) {
+	if column.Lookup == ReferencesID {
+		return value, "", nil
+	}
+
+	collectionID := ReferencedCollection(*im.collection.Field(column.Field))
+	ids, ok := im.refs[collectionID]
+	if !ok {
+		ids = map[string]string{}
+		err := ForEach(im.ctx, im.service, collectionID, model.PaginationParams{Limit: im.opts.PageSize}, func(item model.Item) error {
+			ids[item.Slug] = item.ID
+			ids[item.ID] = item.ID
+			return nil
+		})
+		if err != nil {
+			return "", "", err
+		}
+		im.refs[collectionID] = ids
+	}
+
+	id, ok := ids[value]
+	if !ok {
+		return "", fmt.Sprintf("Referenced item '%s' not found", value), nil
+	}
+
+	return id, "", nil
+}
@@ -0,0 +1,168 @@
+package item_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/item"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/stretchr/testify/assert"
+)
+
+type write struct {
+	method string
+	itemID string
+	req    model.ItemRequest
+}
+
+func (f *fakeItemList) CreateWithContext(ctx context.Context, collectionID string, req model.ItemRequest) (*model.Item, *common.Error) {
+	f.writes = append(f.writes, write{method: http.MethodPost, req: req})
+	if req.Fields["name"] == "Rejected" {
+		return nil, &common.Error{Code: http.StatusBadRequest, Message: "Validation Failure", Name: "ValidationError"}
+	}
+
+	return &model.Item{ID: fmt.Sprintf("new-%d", len(f.writes)), Slug: req.Fields["slug"].(string)}, nil
+}
+
+func (f *fakeItemList) PatchWithContext(ctx context.Context, collectionID string, itemID string, req model.ItemRequest) (*model.Item, *common.Error) {
+	f.writes = append(f.writes, write{method: http.MethodPatch, itemID: itemID, req: req})
+
+	return &model.Item{ID: itemID}, nil
+}
+
+func importCollection() model.Collection {
+	collection := exportCollection()
+	collection.Fields = append(collection.Fields,
+		model.CollectionField{Slug: "published", Type: model.FieldTypeDate},
+		model.CollectionField{Slug: "summary", Type: model.FieldTypePlainText, Required: true},
+	)
+
+	return collection
+}
+
+const importCSV = `Title,Slug,Summary,Minutes,Featured,Category,Author,Co-Authors,Published,Image
+Hello World,hello-world,Updated summary,4,yes,news,arthur-dent,"ford-prefect, a-1",01/03/2022,https://uploads.webflow.com/f4.png
+So Long,,New post,,no,,,,,
+Broken,broken,Broken post,many,maybe,Sports,zaphod,,yesterday,
+Missing,missing,,,,,,,,
+So Long,so-long,Second row of the same post,5,,,,,,
+Rejected,rejected,Rejected by the API,,,,,,,
+`
+
+func importMapping() item.Mapping {
+	return item.Mapping{Columns: []item.ColumnMapping{
+		{Column: "Title", Field: "name"},
+		{Column: "Slug", Field: "slug"},
+		{Column: "Summary", Field: "summary"},
+		{Column: "Minutes", Field: "reading-time"},
+		{Column: "Featured", Field: "featured"},
+		{Column: "Category", Field: "category"},
+		{Column: "Author", Field: "author"},
+		{Column: "Co-Authors", Field: "co-authors"},
+		{Column: "Published", Field: "published", Layout: "02/01/2006"},
+		{Column: "Image", Field: "main-image"},
+	}}
+}
+
+func TestImport(t *testing.T) {
+	fake := &fakeItemList{items: exportItems()}
+
+	results, err := item.Import(context.Background(), fake, importCollection(), strings.NewReader(importCSV), item.ImportOptions{Mapping: importMapping(), Live: true})
+	assert.Nil(t, err)
+	assert.Len(t, results, 6)
+
+	assert.Equal(t, item.ImportResult{Line: 2, Slug: "hello-world", Status: item.ImportUpdated, ItemID: "580e640c8c9a982ac9b8b77a"}, results[0])
+	assert.Equal(t, item.ImportResult{Line: 3, Slug: "so-long", Status: item.ImportCreated, ItemID: "new-2"}, results[1])
+
+	assert.Equal(t, item.ImportFailed, results[2].Status)
+	assert.Equal(t, []interface{}{
+		"Field 'reading-time': Expected a number, got 'many'",
+		"Field 'featured': Expected a boolean, got 'maybe'",
+		"Field 'category': Unknown option 'Sports'",
+		"Field 'author': Referenced item 'zaphod' not found",
+		"Field 'published': Expected a date, got 'yesterday'",
+	}, results[2].Err.Problems)
+	assert.Equal(t, []interface{}{"Field 'summary': Field is required"}, results[3].Err.Problems)
+
+	assert.Equal(t, item.ImportResult{Line: 6, Slug: "so-long", Status: item.ImportUpdated, ItemID: "new-2"}, results[4])
+	assert.Equal(t, item.ImportFailed, results[5].Status)
+	assert.Equal(t, http.StatusBadRequest, results[5].Err.Code)
+
+	assert.Len(t, fake.writes, 4)
+	assert.Equal(t, write{method: http.MethodPatch, itemID: "580e640c8c9a982ac9b8b77a", req: model.ItemRequest{Live: true, Fields: map[string]interface{}{
+		"name":         "Hello World",
+		"slug":         "hello-world",
+		"summary":      "Updated summary",
+		"reading-time": float64(4),
+		"featured":     true,
+		"category":     "a1",
+		"author":       "a-1",
+		"co-authors":   []interface{}{"a-2", "a-1"},
+		"published":    "2022-03-01T00:00:00Z",
+		"main-image":   map[string]interface{}{"url": "https://uploads.webflow.com/f4.png"},
+	}}}, fake.writes[0])
+	assert.Equal(t, map[string]interface{}{
+		"name": "So Long", "slug": "so-long", "summary": "New post", "featured": false, "_archived": false, "_draft": false,
+	}, fake.writes[1].req.Fields)
+	assert.Equal(t, http.MethodPatch, fake.writes[2].method)
+}
+
+func TestImportDryRun(t *testing.T) {
+	fake := &fakeItemList{items: exportItems()}
+
+	results, err := item.Import(context.Background(), fake, importCollection(), strings.NewReader(importCSV), item.ImportOptions{Mapping: importMapping(), DryRun: true})
+	assert.Nil(t, err)
+	assert.Empty(t, fake.writes)
+
+	statuses := make([]string, len(results))
+	for i, result := range results {
+		statuses[i] = result.Status
+	}
+	assert.Equal(t, []string{
+		item.ImportUpdated, item.ImportCreated, item.ImportFailed, item.ImportFailed, item.ImportUpdated, item.ImportCreated,
+	}, statuses)
+	assert.Empty(t, results[1].ItemID)
+}
+
+func TestImportDefaultMapping(t *testing.T) {
+	fake := &fakeItemList{items: exportItems()}
+
+	csv := "_id,name,slug,reading-time,created-on\nignored,Hello World,hello-world,7,2022-01-01T00:00:00Z\n"
+	results, err := item.Import(context.Background(), fake, exportCollection(), strings.NewReader(csv), item.ImportOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, item.ImportUpdated, results[0].Status)
+	assert.Equal(t, map[string]interface{}{"name": "Hello World", "slug": "hello-world", "reading-time": float64(7)}, fake.writes[0].req.Fields)
+}
+
+func TestImportErrors(t *testing.T) {
+	fake := &fakeItemList{items: exportItems()}
+
+	_, err := item.Import(context.Background(), fake, importCollection(), strings.NewReader(importCSV), item.ImportOptions{Mapping: item.Mapping{Columns: []item.ColumnMapping{
+		{Column: "Headline", Field: "name"},
+		{Column: "Title", Field: "title"},
+	}}})
+	assert.Equal(t, `item: invalid mapping: column "Headline" not in CSV; field "title" not in collection post`, err.Message)
+	assert.Empty(t, fake.calls)
+
+	_, err = item.Import(context.Background(), &fakeItemList{}, importCollection(), strings.NewReader(importCSV), item.ImportOptions{Mapping: importMapping()})
+	assert.Equal(t, http.StatusNotFound, err.Code)
+}
+
+func TestLoadMapping(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mapping.json")
+	assert.Nil(t, os.WriteFile(path, []byte(`{"columns": [{"column": "Tags", "field": "tags", "separator": ";", "lookup": "id"}]}`), 0o600))
+
+	mapping, err := item.LoadMapping(path)
+	assert.Nil(t, err)
+	assert.Equal(t, []item.ColumnMapping{{Column: "Tags", Field: "tags", Separator: ";", Lookup: item.ReferencesID}}, mapping.Columns)
+
+	assert.Nil(t, os.WriteFile(path, []byte(`{"columns": {}}`), 0o600))
+	_, err = item.LoadMapping(path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,149 @@
+package item
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/nasrul21/go-webflow/client"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+)
+
+type Item interface {
+	GetList(collectionID string, params model.PaginationParams) (*model.ItemList, *common.Error)
+	GetListWithContext(ctx context.Context, collectionID string, params model.PaginationParams) (*model.ItemList, *common.Error)
+	Get(collectionID string, itemID string) (*model.Item, *common.Error)
+	GetWithContext(ctx context.Context, collectionID string, itemID string) (*model.Item, *common.Error)
+	Create(collectionID string, req model.ItemRequest) (*model.Item, *common.Error)
+	CreateWithContext(ctx context.Context, collectionID string, req model.ItemRequest) (*model.Item, *common.Error)
+	Update(collectionID string, itemID string, req model.ItemRequest) (*model.Item, *common.Error)
+	UpdateWithContext(ctx context.Context, collectionID string, itemID string, req model.ItemRequest) (*model.Item, *common.Error)
+	Patch(collectionID string, itemID string, req model.ItemRequest) (*model.Item, *common.Error)
+	PatchWithContext(ctx context.Context, collectionID string, itemID string, req model.ItemRequest) (*model.Item, *common.Error)
+}
+
+type ItemImpl struct {
+	Opt    *common.Option
+	Client client.Client
+}
+
+func New(opt *common.Option, client client.Client) Item {
+	return &ItemImpl{
+		Opt:    opt,
+		Client: client,
+	}
+}
+
+func (i *ItemImpl) GetList(collectionID string, params model.PaginationParams) (*model.ItemList, *common.Error) {
+	return i.GetListWithContext(context.Background(), collectionID, params)
+}
+
+func (i *ItemImpl) GetListWithContext(ctx context.Context, collectionID string, params model.PaginationParams) (*model.ItemList, *common.Error) {
+	var response model.ItemList
+	var header http.Header
+
+	url := fmt.Sprintf("%s/collections/%s/items", i.Opt.BaseURL, collectionID)
+	if query := params.Query().Encode(); query != "" {
+		url += "?" + query
+	}
+
+	err := i.Client.Call(
+		ctx,
+		http.MethodGet,
+		url,
+		i.Opt.ApiKey,
+		header,
+		nil,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (i *ItemImpl) Get(collectionID string, itemID string) (*model.Item, *common.Error) {
+	return i.GetWithContext(context.Background(), collectionID, itemID)
+}
+
+// GetWithContext returns an item. The API answers with a list holding the
+// single item.
+func (i *ItemImpl) GetWithContext(ctx context.Context, collectionID string, itemID string) (*model.Item, *common.Error) {
+	var response model.ItemList
+	var header http.Header
+
+	err := i.Client.Call(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/collections/%s/items/%s", i.Opt.BaseURL, collectionID, itemID),
+		i.Opt.ApiKey,
+		header,
+		nil,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Items) == 0 {
+		return nil, &common.Error{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("item %s not found", itemID),
+			Name:    "NotFound",
+		}
+	}
+
+	return &response.Items[0], nil
+}
+
+func (i *ItemImpl) Create(collectionID string, req model.ItemRequest) (*model.Item, *common.Error) {
+	return i.CreateWithContext(context.Background(), collectionID, req)
+}
+
+func (i *ItemImpl) CreateWithContext(ctx context.Context, collectionID string, req model.ItemRequest) (*model.Item, *common.Error) {
+	return i.write(ctx, http.MethodPost, fmt.Sprintf("%s/collections/%s/items", i.Opt.BaseURL, collectionID), req)
+}
+
+// Update replaces every field of an item.
+func (i *ItemImpl) Update(collectionID string, itemID string, req model.ItemRequest) (*model.Item, *common.Error) {
+	return i.UpdateWithContext(context.Background(), collectionID, itemID, req)
+}
+
+func (i *ItemImpl) UpdateWithContext(ctx context.Context, collectionID string, itemID string, req model.ItemRequest) (*model.Item, *common.Error) {
+	return i.write(ctx, http.MethodPut, fmt.Sprintf("%s/collections/%s/items/%s", i.Opt.BaseURL, collectionID, itemID), req)
+}
+
+// Patch changes the given fields of an item and leaves the others as they
+// are.
+func (i *ItemImpl) Patch(collectionID string, itemID string, req model.ItemRequest) (*model.Item, *common.Error) {
+	return i.PatchWithContext(context.Background(), collectionID, itemID, req)
+}
+
+func (i *ItemImpl) PatchWithContext(ctx context.Context, collectionID string, itemID string, req model.ItemRequest) (*model.Item, *common.Error) {
+	return i.write(ctx, http.MethodPatch, fmt.Sprintf("%s/collections/%s/items/%s", i.Opt.BaseURL, collectionID, itemID), req)
+}
+
+func (i *ItemImpl) write(ctx context.Context, method string, url string, req model.ItemRequest) (*model.Item, *common.Error) {
+	var response model.Item
+	var header http.Header
+
+	if req.Live {
+		url += "?live=true"
+	}
+
+	err := i.Client.Call(
+		ctx,
+		method,
+		url,
+		i.Opt.ApiKey,
+		header,
+		req,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,271 @@
+package item_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/nasrul21/go-webflow"
+	"github.com/nasrul21/go-webflow/client/mock"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	collectionID = "580e63fc8c9a982ac9b8b745"
+	itemID       = "580e640c8c9a982ac9b8b77a"
+)
+
+var itemList = `{
+	"items": [
+		{
+			"_archived": false,
+			"_draft": false,
+			"name": "Hello World",
+			"slug": "hello-world",
+			"author": "580e640c8c9a982ac9b8b778",
+			"_cid": "580e63fc8c9a982ac9b8b745",
+			"_id": "580e640c8c9a982ac9b8b77a"
+		}
+	],
+	"count": 1,
+	"limit": 1,
+	"offset": 0,
+	"total": 5
+}`
+
+var helloWorld = model.Item{
+	ID:           itemID,
+	CollectionID: collectionID,
+	Name:         "Hello World",
+	Slug:         "hello-world",
+	Fields:       map[string]interface{}{"author": "580e640c8c9a982ac9b8b778"},
+}
+
+func TestGetList(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		_ = json.Unmarshal([]byte(itemList), &result)
+
+		return nil
+	}
+
+	testcases := []struct {
+		desc        string
+		params      model.PaginationParams
+		mockClosure func()
+		expectedRes *model.ItemList
+		expectedErr *common.Error
+	}{
+		{
+			desc:   "should get list items",
+			params: model.PaginationParams{Offset: 0, Limit: 1},
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/collections/%s/items?limit=1", wf.Opt.BaseURL, collectionID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&model.ItemList{},
+				).Return(nil).Once()
+			},
+			expectedRes: &model.ItemList{
+				Items: []model.Item{helloWorld},
+				Count: 1,
+				Limit: 1,
+				Total: 5,
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "should return error",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/collections/%s/items", wf.Opt.BaseURL, collectionID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&model.ItemList{},
+				).Return(common.FromGoErr(fmt.Errorf("some error"))).Once()
+			},
+			expectedRes: nil,
+			expectedErr: common.FromGoErr(fmt.Errorf("some error")),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.mockClosure()
+
+			resp, err := wf.Item.GetList(collectionID, tc.params)
+
+			assert.Equal(t, tc.expectedRes, resp)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		_ = json.Unmarshal([]byte(itemList), &result)
+
+		return nil
+	}
+
+	testcases := []struct {
+		desc        string
+		mockClosure func()
+		expectedRes *model.Item
+		expectedErr *common.Error
+	}{
+		{
+			desc: "should get item",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/collections/%s/items/%s", wf.Opt.BaseURL, collectionID, itemID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&model.ItemList{},
+				).Return(nil).Once()
+			},
+			expectedRes: &helloWorld,
+			expectedErr: nil,
+		},
+		{
+			desc: "should return error",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/collections/%s/items/%s", wf.Opt.BaseURL, collectionID, itemID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&model.ItemList{},
+				).Return(common.FromGoErr(fmt.Errorf("some error"))).Once()
+			},
+			expectedRes: nil,
+			expectedErr: common.FromGoErr(fmt.Errorf("some error")),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.mockClosure()
+
+			resp, err := wf.Item.Get(collectionID, itemID)
+
+			assert.Equal(t, tc.expectedRes, resp)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		_ = json.Unmarshal([]byte(`{
+			"_archived": false,
+			"_draft": false,
+			"name": "Hello World",
+			"slug": "hello-world",
+			"author": "580e640c8c9a982ac9b8b778",
+			"_cid": "580e63fc8c9a982ac9b8b745",
+			"_id": "580e640c8c9a982ac9b8b77a"
+		}`), &result)
+
+		return nil
+	}
+
+	req := model.ItemRequest{Fields: map[string]interface{}{"name": "Hello World", "author": "580e640c8c9a982ac9b8b778"}}
+	live := req
+	live.Live = true
+
+	testcases := []struct {
+		desc        string
+		method      string
+		url         string
+		call        func() (*model.Item, *common.Error)
+		err         *common.Error
+		expectedRes *model.Item
+	}{
+		{
+			desc:        "should create live item",
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/collections/%s/items?live=true", wf.Opt.BaseURL, collectionID),
+			call:        func() (*model.Item, *common.Error) { return wf.Item.Create(collectionID, live) },
+			expectedRes: &helloWorld,
+		},
+		{
+			desc:        "should update item",
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/collections/%s/items/%s", wf.Opt.BaseURL, collectionID, itemID),
+			call:        func() (*model.Item, *common.Error) { return wf.Item.Update(collectionID, itemID, req) },
+			expectedRes: &helloWorld,
+		},
+		{
+			desc:        "should patch item",
+			method:      http.MethodPatch,
+			url:         fmt.Sprintf("%s/collections/%s/items/%s", wf.Opt.BaseURL, collectionID, itemID),
+			call:        func() (*model.Item, *common.Error) { return wf.Item.Patch(collectionID, itemID, req) },
+			expectedRes: &helloWorld,
+		},
+		{
+			desc:   "should return error",
+			method: http.MethodPatch,
+			url:    fmt.Sprintf("%s/collections/%s/items/%s", wf.Opt.BaseURL, collectionID, itemID),
+			call:   func() (*model.Item, *common.Error) { return wf.Item.Patch(collectionID, itemID, req) },
+			err:    common.FromGoErr(fmt.Errorf("some error")),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			body := req
+			if tc.method == http.MethodPost {
+				body = live
+			}
+			call := httpClientMockObj.On(
+				"Call",
+				context.Background(),
+				tc.method,
+				tc.url,
+				wf.Opt.ApiKey,
+				http.Header(nil),
+				body,
+				&model.Item{},
+			)
+			if tc.err != nil {
+				call.Return(tc.err).Once()
+			} else {
+				call.Return(nil).Once()
+			}
+
+			resp, err := tc.call()
+
+			assert.Equal(t, tc.expectedRes, resp)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/item"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// ItemMock is a testify mock of item.Item.
+type ItemMock struct {
+	mock.Mock
+}
+
+var _ item.Item = (*ItemMock)(nil)
+
+func (m *ItemMock) GetList(collectionID string, params model.PaginationParams) (*model.ItemList, *common.Error) {
+	args := m.Called(collectionID, params)
+	res, _ := args.Get(0).(*model.ItemList)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *ItemMock) GetListWithContext(ctx context.Context, collectionID string, params model.PaginationParams) (*model.ItemList, *common.Error) {
+	args := m.Called(ctx, collectionID, params)
+	res, _ := args.Get(0).(*model.ItemList)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *ItemMock) Get(collectionID string, itemID string) (*model.Item, *common.Error) {
+	args := m.Called(collectionID, itemID)
+	res, _ := args.Get(0).(*model.Item)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *ItemMock) GetWithContext(ctx context.Context, collectionID string, itemID string) (*model.Item, *common.Error) {
+	args := m.Called(ctx, collectionID, itemID)
+	res, _ := args.Get(0).(*model.Item)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *ItemMock) Create(collectionID string, req model.ItemRequest) (*model.Item, *common.Error) {
+	args := m.Called(collectionID, req)
+	res, _ := args.Get(0).(*model.Item)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *ItemMock) CreateWithContext(ctx context.Context, collectionID string, req model.ItemRequest) (*model.Item, *common.Error) {
+	args := m.Called(ctx, collectionID, req)
+	res, _ := args.Get(0).(*model.Item)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *ItemMock) Update(collectionID string, itemID string, req model.ItemRequest) (*model.Item, *common.Error) {
+	args := m.Called(collectionID, itemID, req)
+	res, _ := args.Get(0).(*model.Item)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *ItemMock) UpdateWithContext(ctx context.Context, collectionID string, itemID string, req model.ItemRequest) (*model.Item, *common.Error) {
+	args := m.Called(ctx, collectionID, itemID, req)
+	res, _ := args.Get(0).(*model.Item)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *ItemMock) Patch(collectionID string, itemID string, req model.ItemRequest) (*model.Item, *common.Error) {
+	args := m.Called(collectionID, itemID, req)
+	res, _ := args.Get(0).(*model.Item)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *ItemMock) PatchWithContext(ctx context.Context, collectionID string, itemID string, req model.ItemRequest) (*model.Item, *common.Error) {
+	args := m.Called(ctx, collectionID, itemID, req)
+	res, _ := args.Get(0).(*model.Item)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
@@ -0,0 +1,49 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/meta"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// MetaMock is a testify mock of meta.Meta.
+type MetaMock struct {
+	mock.Mock
+}
+
+var _ meta.Meta = (*MetaMock)(nil)
+
+func (m *MetaMock) GetInfo() (*model.AuthorizationInfo, *common.Error) {
+	args := m.Called()
+	res, _ := args.Get(0).(*model.AuthorizationInfo)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *MetaMock) GetInfoWithContext(ctx context.Context) (*model.AuthorizationInfo, *common.Error) {
+	args := m.Called(ctx)
+	res, _ := args.Get(0).(*model.AuthorizationInfo)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *MetaMock) GetUser() (*model.AuthorizedUser, *common.Error) {
+	args := m.Called()
+	res, _ := args.Get(0).(*model.AuthorizedUser)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *MetaMock) GetUserWithContext(ctx context.Context) (*model.AuthorizedUser, *common.Error) {
+	args := m.Called(ctx)
+	res, _ := args.Get(0).(*model.AuthorizedUser)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
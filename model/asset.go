@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+type Asset struct {
+	ID               string    `json:"id"`
+	ContentType      string    `json:"contentType"`
+	Size             int64     `json:"size"`
+	HostedURL        string    `json:"hostedUrl"`
+	OriginalFileName string    `json:"originalFileName"`
+	DisplayName      string    `json:"displayName"`
+	AltText          string    `json:"altText,omitempty"`
+	SiteID           string    `json:"siteId,omitempty"`
+	ParentFolder     string    `json:"parentFolder,omitempty"`
+	FileHash         string    `json:"fileHash,omitempty"`
+	CreatedOn        time.Time `json:"createdOn"`
+	LastUpdated      time.Time `json:"lastUpdated"`
+}
+
+type CreateAssetRequest struct {
+	FileName string `json:"fileName"`
+	FileHash string `json:"fileHash"`
+}
+
+// AssetUpload is the asset metadata returned by Webflow together with the
+// presigned target the file content must be uploaded to.
+type AssetUpload struct {
+	Asset
+	UploadURL     string            `json:"uploadUrl"`
+	UploadDetails map[string]string `json:"uploadDetails"`
+}
+
+type AssetList struct {
+	Assets []Asset `json:"assets"`
+}
+
+// UpdateAssetRequest only sends the fields that are set, so nil leaves the
+// current value untouched and an empty string clears it.
+type UpdateAssetRequest struct {
+	DisplayName  *string `json:"displayName,omitempty"`
+	AltText      *string `json:"altText,omitempty"`
+	ParentFolder *string `json:"parentFolder,omitempty"`
+}
+
+type AssetFolder struct {
+	ID           string    `json:"id"`
+	DisplayName  string    `json:"displayName"`
+	ParentFolder string    `json:"parentFolder,omitempty"`
+	Assets       []string  `json:"assets"`
+	SiteID       string    `json:"siteId"`
+	CreatedOn    time.Time `json:"createdOn"`
+	LastUpdated  time.Time `json:"lastUpdated"`
+}
+
+type AssetFolderList struct {
+	AssetFolders []AssetFolder `json:"assetFolders"`
+}
+
+type CreateAssetFolderRequest struct {
+	DisplayName  string `json:"displayName"`
+	ParentFolder string `json:"parentFolder,omitempty"`
+}
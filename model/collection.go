@@ -0,0 +1,185 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+const (
+	FieldTypePlainText  string = "PlainText"
+	FieldTypeRichText   string = "RichText"
+	FieldTypeImage      string = "ImageRef"
+	FieldTypeImageSet   string = "Set"
+	FieldTypeVideo      string = "Video"
+	FieldTypeLink       string = "Link"
+	FieldTypeEmail      string = "Email"
+	FieldTypePhone      string = "Phone"
+	FieldTypeNumber     string = "Number"
+	FieldTypeDate       string = "Date"
+	FieldTypeBool       string = "Bool"
+	FieldTypeColor      string = "Color"
+	FieldTypeOption     string = "Option"
+	FieldTypeItemRef    string = "ItemRef"
+	FieldTypeItemRefSet string = "ItemRefSet"
+)
+
+type Collection struct {
+	ID           string            `json:"_id"`
+	LastUpdated  *time.Time        `json:"lastUpdated,omitempty"`
+	CreatedOn    *time.Time        `json:"createdOn,omitempty"`
+	Name         string            `json:"name"`
+	Slug         string            `json:"slug"`
+	SingularName string            `json:"singularName"`
+	Fields       []CollectionField `json:"fields,omitempty"`
+}
+
+type CollectionField struct {
+	ID          string                 `json:"id"`
+	Type        string                 `json:"type"`
+	Slug        string                 `json:"slug"`
+	Name        string                 `json:"name"`
+	Required    bool                   `json:"required"`
+	Editable    bool                   `json:"editable"`
+	Validations map[string]interface{} `json:"validations,omitempty"`
+}
+
+// Field returns the field with the given slug, nil when the collection has
+// none.
+func (c *Collection) Field(slug string) *CollectionField {
+	for i, field := range c.Fields {
+		if field.Slug == slug {
+			return &c.Fields[i]
+		}
+	}
+
+	return nil
+}
+
+// Item is a collection item. The fields every item has are typed; the
+// fields defined by the collection schema are kept in Fields by slug.
+type Item struct {
+	ID           string
+	CollectionID string
+	Archived     bool
+	Draft        bool
+	Name         string
+	Slug         string
+	CreatedOn    *time.Time
+	UpdatedOn    *time.Time
+	PublishedOn  *time.Time
+	Fields       map[string]interface{}
+}
+
+type itemJSON struct {
+	ID           string     `json:"_id,omitempty"`
+	CollectionID string     `json:"_cid,omitempty"`
+	Archived     bool       `json:"_archived"`
+	Draft        bool       `json:"_draft"`
+	Name         string     `json:"name"`
+	Slug         string     `json:"slug"`
+	CreatedOn    *time.Time `json:"created-on,omitempty"`
+	UpdatedOn    *time.Time `json:"updated-on,omitempty"`
+	PublishedOn  *time.Time `json:"published-on,omitempty"`
+}
+
+var itemKeys = []string{"_id", "_cid", "_archived", "_draft", "name", "slug", "created-on", "updated-on", "published-on"}
+
+func (i Item) MarshalJSON() ([]byte, error) {
+	known, err := json.Marshal(itemJSON{
+		ID:           i.ID,
+		CollectionID: i.CollectionID,
+		Archived:     i.Archived,
+		Draft:        i.Draft,
+		Name:         i.Name,
+		Slug:         i.Slug,
+		CreatedOn:    i.CreatedOn,
+		UpdatedOn:    i.UpdatedOn,
+		PublishedOn:  i.PublishedOn,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(known, &fields); err != nil {
+		return nil, err
+	}
+	for slug, value := range i.Fields {
+		if _, ok := fields[slug]; ok {
+			continue
+		}
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		fields[slug] = raw
+	}
+
+	return json.Marshal(fields)
+}
+
+func (i *Item) UnmarshalJSON(data []byte) error {
+	var known itemJSON
+	if err := json.Unmarshal(data, &known); err != nil {
+		return err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for _, key := range itemKeys {
+		delete(fields, key)
+	}
+
+	*i = Item{
+		ID:           known.ID,
+		CollectionID: known.CollectionID,
+		Archived:     known.Archived,
+		Draft:        known.Draft,
+		Name:         known.Name,
+		Slug:         known.Slug,
+		CreatedOn:    known.CreatedOn,
+		UpdatedOn:    known.UpdatedOn,
+		PublishedOn:  known.PublishedOn,
+		Fields:       fields,
+	}
+
+	return nil
+}
+
+type ItemList struct {
+	Items  []Item `json:"items"`
+	Count  int    `json:"count"`
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+	Total  int    `json:"total"`
+}
+
+// Slugify turns s into a slug: lowercase ASCII letters and digits with every
+// other run of characters replaced by a single dash.
+func Slugify(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// ItemRequest holds the fields of an item to create or change, by slug.
+// Creating and replacing an item needs name, slug, _archived and _draft.
+type ItemRequest struct {
+	Fields map[string]interface{} `json:"fields"`
+	// Live publishes the change at once instead of staging it for the next
+	// site publish.
+	Live bool `json:"-"`
+}
@@ -0,0 +1,34 @@
+package model_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItemJSON(t *testing.T) {
+	data := `{"_id": "580e640c8c9a982ac9b8b77a", "_cid": "580e63fc8c9a982ac9b8b745", "_archived": false, "_draft": true, "name": "Hello World", "slug": "hello-world", "author": "Arthur Dent", "reading-time": 3}`
+
+	var item model.Item
+	assert.Nil(t, json.Unmarshal([]byte(data), &item))
+	assert.Equal(t, model.Item{
+		ID:           "580e640c8c9a982ac9b8b77a",
+		CollectionID: "580e63fc8c9a982ac9b8b745",
+		Draft:        true,
+		Name:         "Hello World",
+		Slug:         "hello-world",
+		Fields:       map[string]interface{}{"author": "Arthur Dent", "reading-time": float64(3)},
+	}, item)
+
+	body, err := json.Marshal(item)
+	assert.Nil(t, err)
+	assert.JSONEq(t, data, string(body))
+}
+
+func TestSlugify(t *testing.T) {
+	assert.Equal(t, "hello-world", model.Slugify("Hello World"))
+	assert.Equal(t, "t-shirt-xl", model.Slugify("  T-Shirt / XL!"))
+	assert.Equal(t, "", model.Slugify("---"))
+}
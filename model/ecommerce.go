@@ -0,0 +1,9 @@
+package model
+
+import "time"
+
+type EcommerceSettings struct {
+	Site            string    `json:"site"`
+	CreatedOn       time.Time `json:"createdOn"`
+	DefaultCurrency string    `json:"defaultCurrency"`
+}
@@ -0,0 +1,47 @@
+package model
+
+import "encoding/json"
+
+const (
+	InventoryTypeFinite   string = "finite"
+	InventoryTypeInfinite string = "infinite"
+)
+
+type Inventory struct {
+	ID            string `json:"_id"`
+	Quantity      int    `json:"quantity"`
+	InventoryType string `json:"inventoryType"`
+}
+
+// UpdateInventoryRequest either sets Quantity or adjusts the current
+// quantity by UpdateQuantity; Webflow rejects requests setting both.
+type UpdateInventoryRequest struct {
+	InventoryType  string `json:"inventoryType"`
+	Quantity       *int   `json:"quantity,omitempty"`
+	UpdateQuantity *int   `json:"updateQuantity,omitempty"`
+}
+
+func (r UpdateInventoryRequest) MarshalJSON() ([]byte, error) {
+	type fields UpdateInventoryRequest
+	return json.Marshal(fieldsEnvelope{Fields: fields(r)})
+}
+
+func SetInventoryQuantity(quantity int) UpdateInventoryRequest {
+	return UpdateInventoryRequest{
+		InventoryType: InventoryTypeFinite,
+		Quantity:      &quantity,
+	}
+}
+
+func AdjustInventoryQuantity(delta int) UpdateInventoryRequest {
+	return UpdateInventoryRequest{
+		InventoryType:  InventoryTypeFinite,
+		UpdateQuantity: &delta,
+	}
+}
+
+func InfiniteInventory() UpdateInventoryRequest {
+	return UpdateInventoryRequest{
+		InventoryType: InventoryTypeInfinite,
+	}
+}
@@ -0,0 +1,266 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in the smallest unit of its currency, e.g. cents, as
+// Webflow represents prices and totals. Unit is the ISO 4217 currency code.
+type Money struct {
+	Unit  string `json:"unit"`
+	Value int64  `json:"value"`
+	// Display is the amount formatted by Webflow. It is only set on values
+	// decoded from the API and is dropped by arithmetic.
+	Display string `json:"string,omitempty"`
+}
+
+type CurrencyMismatchError struct {
+	Left  string
+	Right string
+}
+
+func (e *CurrencyMismatchError) Error() string {
+	return fmt.Sprintf("money: currency mismatch %s and %s", e.Left, e.Right)
+}
+
+var zeroDecimalCurrencies = map[string]bool{
+	"BIF": true, "CLP": true, "DJF": true, "GNF": true, "JPY": true, "KMF": true,
+	"KRW": true, "MGA": true, "PYG": true, "RWF": true, "UGX": true, "VND": true,
+	"VUV": true, "XAF": true, "XOF": true, "XPF": true,
+}
+
+var currencySymbols = map[string]string{
+	"AUD": "A$", "CAD": "CA$", "EUR": "€", "GBP": "£", "INR": "₹",
+	"JPY": "¥", "NZD": "NZ$", "USD": "$",
+}
+
+func NewMoney(value int64, unit string) Money {
+	return Money{
+		Unit:  strings.ToUpper(unit),
+		Value: value,
+	}
+}
+
+// ParseMoney parses an amount in major units, e.g. "1234.50", into a Money
+// of the given currency. It rejects amounts with more decimals than the
+// currency has.
+func ParseMoney(amount string, unit string) (Money, error) {
+	money := NewMoney(0, unit)
+	if money.Unit == "" {
+		return Money{}, fmt.Errorf("money: missing currency for amount %q", amount)
+	}
+
+	text := strings.TrimSpace(amount)
+	negative := strings.HasPrefix(text, "-")
+	text = strings.TrimPrefix(text, "-")
+
+	whole, fraction := text, ""
+	if i := strings.IndexByte(text, '.'); i >= 0 {
+		whole, fraction = text[:i], text[i+1:]
+	}
+
+	decimals := money.Decimals()
+	if whole == "" || len(fraction) > decimals || !isDigits(whole) || !isDigits(fraction) {
+		return Money{}, fmt.Errorf("money: invalid amount %q for %s", amount, money.Unit)
+	}
+
+	value, err := strconv.ParseInt(whole+fraction+strings.Repeat("0", decimals-len(fraction)), 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("money: invalid amount %q for %s", amount, money.Unit)
+	}
+	if negative {
+		value = -value
+	}
+	money.Value = value
+
+	return money, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DefaultCurrency sets unit on every value that has none and reports how
+// many were changed.
+func DefaultCurrency(unit string, values ...*Money) int {
+	changed := 0
+	for _, value := range values {
+		if value != nil && value.Unit == "" {
+			value.Unit = unit
+			changed++
+		}
+	}
+
+	return changed
+}
+
+// MissingCurrency reports whether any of values has no unit.
+func MissingCurrency(values ...*Money) bool {
+	for _, value := range values {
+		if value != nil && value.Unit == "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m Money) IsZero() bool {
+	return m.Value == 0
+}
+
+// SameCurrency reports whether m and o can be combined. A zero value without
+// unit is compatible with any currency so it can be used as an accumulator.
+func (m Money) SameCurrency(o Money) bool {
+	return m.Unit == o.Unit || (m.Unit == "" && m.Value == 0) || (o.Unit == "" && o.Value == 0)
+}
+
+func (m Money) Add(o Money) (Money, error) {
+	if !m.SameCurrency(o) {
+		return Money{}, &CurrencyMismatchError{Left: m.Unit, Right: o.Unit}
+	}
+
+	return Money{Unit: m.unit(o), Value: m.Value + o.Value}, nil
+}
+
+func (m Money) Sub(o Money) (Money, error) {
+	if !m.SameCurrency(o) {
+		return Money{}, &CurrencyMismatchError{Left: m.Unit, Right: o.Unit}
+	}
+
+	return Money{Unit: m.unit(o), Value: m.Value - o.Value}, nil
+}
+
+func (m Money) Mul(n int64) Money {
+	return Money{Unit: m.Unit, Value: m.Value * n}
+}
+
+// Cmp returns -1, 0 or 1 when m is less than, equal to or greater than o.
+func (m Money) Cmp(o Money) (int, error) {
+	if !m.SameCurrency(o) {
+		return 0, &CurrencyMismatchError{Left: m.Unit, Right: o.Unit}
+	}
+
+	switch {
+	case m.Value < o.Value:
+		return -1, nil
+	case m.Value > o.Value:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+func (m Money) unit(o Money) string {
+	if m.Unit != "" {
+		return m.Unit
+	}
+
+	return o.Unit
+}
+
+// Decimals returns the number of minor unit digits of the currency.
+func (m Money) Decimals() int {
+	if zeroDecimalCurrencies[m.Unit] {
+		return 0
+	}
+
+	return 2
+}
+
+// Amount formats the value in major units without symbol or grouping, e.g.
+// "1234.50" for 123450 USD.
+func (m Money) Amount() string {
+	decimals := m.Decimals()
+	value := m.Value
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	digits := strconv.FormatInt(value, 10)
+	if decimals == 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-decimals] + "." + digits[len(digits)-decimals:]
+}
+
+// String returns the amount formatted by Webflow when known, otherwise the
+// amount with its currency symbol and thousands separators, e.g. "$1,234.50".
+func (m Money) String() string {
+	if m.Display != "" {
+		return m.Display
+	}
+
+	amount := m.Amount()
+	sign := ""
+	if strings.HasPrefix(amount, "-") {
+		sign = "-"
+		amount = amount[1:]
+	}
+
+	whole, fraction := amount, ""
+	if i := strings.IndexByte(amount, '.'); i >= 0 {
+		whole, fraction = amount[:i], amount[i:]
+	}
+
+	var grouped strings.Builder
+	for i, r := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(r)
+	}
+
+	if symbol, ok := currencySymbols[m.Unit]; ok {
+		return sign + symbol + grouped.String() + fraction
+	}
+
+	return strings.TrimSpace(sign + grouped.String() + fraction + " " + m.Unit)
+}
+
+// UnmarshalJSON accepts the value as a JSON number or a numeric string, as
+// different Webflow endpoints use both.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Unit    string          `json:"unit"`
+		Value   json.RawMessage `json:"value"`
+		Display string          `json:"string"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var value int64
+	if len(raw.Value) > 0 && string(raw.Value) != "null" {
+		text := strings.Trim(string(raw.Value), `"`)
+		parsed, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return fmt.Errorf("money: invalid value %s", raw.Value)
+		}
+		value = parsed
+	}
+
+	*m = Money{
+		Unit:    raw.Unit,
+		Value:   value,
+		Display: raw.Display,
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package model_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoneyArithmetic(t *testing.T) {
+	price := model.NewMoney(5500, "usd")
+	tax := model.NewMoney(220, "USD")
+
+	total, err := price.Add(tax)
+	assert.Nil(t, err)
+	assert.Equal(t, model.Money{Unit: "USD", Value: 5720}, total)
+
+	diff, err := price.Sub(tax)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5280), diff.Value)
+
+	assert.Equal(t, model.Money{Unit: "USD", Value: 16500}, price.Mul(3))
+
+	sum := model.Money{}
+	sum, err = sum.Add(price)
+	assert.Nil(t, err)
+	assert.Equal(t, price, sum)
+
+	cmp, err := price.Cmp(tax)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, cmp)
+
+	_, err = price.Add(model.NewMoney(100, "EUR"))
+	assert.Equal(t, &model.CurrencyMismatchError{Left: "USD", Right: "EUR"}, err)
+
+	_, err = price.Cmp(model.NewMoney(100, "EUR"))
+	assert.NotNil(t, err)
+}
+
+func TestMoneyString(t *testing.T) {
+	testcases := []struct {
+		desc     string
+		money    model.Money
+		expected string
+	}{
+		{desc: "should format usd", money: model.NewMoney(123456789, "USD"), expected: "$1,234,567.89"},
+		{desc: "should format cents", money: model.NewMoney(5, "EUR"), expected: "€0.05"},
+		{desc: "should format negative", money: model.NewMoney(-2050, "GBP"), expected: "-£20.50"},
+		{desc: "should format zero decimal currency", money: model.NewMoney(1500, "JPY"), expected: "¥1,500"},
+		{desc: "should format unknown currency with code", money: model.NewMoney(1999, "CHF"), expected: "19.99 CHF"},
+		{desc: "should prefer webflow display", money: model.Money{Unit: "USD", Value: 6099, Display: "$60.99 USD"}, expected: "$60.99 USD"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.money.String())
+		})
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	testcases := []struct {
+		desc     string
+		data     string
+		expected model.Money
+		hasErr   bool
+	}{
+		{
+			desc:     "should decode number value",
+			data:     `{"unit": "USD", "value": 6099, "string": "$60.99"}`,
+			expected: model.Money{Unit: "USD", Value: 6099, Display: "$60.99"},
+		},
+		{
+			desc:     "should decode string value",
+			data:     `{"unit": "USD", "value": "6099", "string": "$60.99"}`,
+			expected: model.Money{Unit: "USD", Value: 6099, Display: "$60.99"},
+		},
+		{
+			desc:   "should reject fractional value",
+			data:   `{"unit": "USD", "value": 60.99}`,
+			hasErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var money model.Money
+			err := json.Unmarshal([]byte(tc.data), &money)
+
+			if tc.hasErr {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, money)
+
+			data, err := json.Marshal(money)
+			assert.Nil(t, err)
+			assert.JSONEq(t, `{"unit": "USD", "value": 6099, "string": "$60.99"}`, string(data))
+		})
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	testcases := []struct {
+		desc        string
+		amount      string
+		unit        string
+		expected    model.Money
+		expectedErr bool
+	}{
+		{desc: "should parse decimals", amount: "1234.50", unit: "usd", expected: model.NewMoney(123450, "USD")},
+		{desc: "should parse whole amount", amount: "12", unit: "EUR", expected: model.NewMoney(1200, "EUR")},
+		{desc: "should parse single decimal", amount: "0.5", unit: "USD", expected: model.NewMoney(50, "USD")},
+		{desc: "should parse negative", amount: "-3.25", unit: "GBP", expected: model.NewMoney(-325, "GBP")},
+		{desc: "should parse zero decimal currency", amount: "1500", unit: "JPY", expected: model.NewMoney(1500, "JPY")},
+		{desc: "should reject decimals for zero decimal currency", amount: "1500.5", unit: "JPY", expectedErr: true},
+		{desc: "should reject too many decimals", amount: "1.234", unit: "USD", expectedErr: true},
+		{desc: "should reject text", amount: "$12", unit: "USD", expectedErr: true},
+		{desc: "should reject empty amount", amount: "", unit: "USD", expectedErr: true},
+		{desc: "should reject missing currency", amount: "12", unit: "", expectedErr: true},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			money, err := model.ParseMoney(tc.amount, tc.unit)
+
+			assert.Equal(t, tc.expectedErr, err != nil)
+			assert.Equal(t, tc.expected, money)
+		})
+	}
+}
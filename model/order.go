@@ -0,0 +1,151 @@
+package model
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+)
+
+const (
+	OrderStatusPending     string = "pending"
+	OrderStatusUnfulfilled string = "unfulfilled"
+	OrderStatusFulfilled   string = "fulfilled"
+	OrderStatusDisputed    string = "disputed"
+	OrderStatusDisputeLost string = "dispute-lost"
+	OrderStatusRefunded    string = "refunded"
+)
+
+type Order struct {
+	OrderID             string                 `json:"orderId"`
+	Status              string                 `json:"status"`
+	Comment             string                 `json:"comment"`
+	OrderComment        string                 `json:"orderComment"`
+	AcceptedOn          *time.Time             `json:"acceptedOn"`
+	FulfilledOn         *time.Time             `json:"fulfilledOn"`
+	RefundedOn          *time.Time             `json:"refundedOn"`
+	DisputedOn          *time.Time             `json:"disputedOn"`
+	DisputeUpdatedOn    *time.Time             `json:"disputeUpdatedOn"`
+	DisputeLastStatus   string                 `json:"disputeLastStatus"`
+	CustomerPaid        Money                  `json:"customerPaid"`
+	NetAmount           Money                  `json:"netAmount"`
+	ApplicationFee      *Money                 `json:"applicationFee,omitempty"`
+	AllAddresses        []OrderAddress         `json:"allAddresses"`
+	ShippingAddress     *OrderAddress          `json:"shippingAddress"`
+	BillingAddress      *OrderAddress          `json:"billingAddress"`
+	ShippingProvider    string                 `json:"shippingProvider"`
+	ShippingTracking    string                 `json:"shippingTracking"`
+	ShippingTrackingURL string                 `json:"shippingTrackingURL"`
+	CustomerInfo        OrderCustomerInfo      `json:"customerInfo"`
+	PurchasedItems      []OrderPurchasedItem   `json:"purchasedItems"`
+	PurchasedItemsCount int                    `json:"purchasedItemsCount"`
+	Totals              OrderTotals            `json:"totals"`
+	StripeDetails       *OrderStripeDetails    `json:"stripeDetails,omitempty"`
+	PaymentProcessor    string                 `json:"paymentProcessor"`
+	CustomData          []map[string]string    `json:"customData,omitempty"`
+	Metadata            map[string]interface{} `json:"metadata,omitempty"`
+	IsCustomerDeleted   bool                   `json:"isCustomerDeleted"`
+	IsShippingRequired  bool                   `json:"isShippingRequired"`
+	HasDownloads        bool                   `json:"hasDownloads"`
+}
+
+// Amounts returns pointers to every money value of the order, including
+// line items and totals.
+func (o *Order) Amounts() []*Money {
+	amounts := []*Money{&o.CustomerPaid, &o.NetAmount, o.ApplicationFee, &o.Totals.Subtotal, &o.Totals.Total}
+	for i := range o.PurchasedItems {
+		amounts = append(amounts, &o.PurchasedItems[i].RowTotal, &o.PurchasedItems[i].VariantPrice)
+	}
+	for i := range o.Totals.Extras {
+		amounts = append(amounts, &o.Totals.Extras[i].Price)
+	}
+
+	return amounts
+}
+
+type OrderAddress struct {
+	Type       string `json:"type"`
+	Addressee  string `json:"addressee"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+	Country    string `json:"country"`
+	PostalCode string `json:"postalCode"`
+}
+
+type OrderCustomerInfo struct {
+	FullName string `json:"fullName"`
+	Email    string `json:"email"`
+}
+
+type OrderPurchasedItem struct {
+	Count        int      `json:"count"`
+	RowTotal     Money    `json:"rowTotal"`
+	ProductID    string   `json:"productId"`
+	ProductName  string   `json:"productName"`
+	ProductSlug  string   `json:"productSlug"`
+	VariantID    string   `json:"variantId"`
+	VariantName  string   `json:"variantName"`
+	VariantSlug  string   `json:"variantSlug"`
+	VariantSKU   string   `json:"variantSKU"`
+	VariantImage *Image   `json:"variantImage,omitempty"`
+	VariantPrice Money    `json:"variantPrice"`
+	Weight       *float64 `json:"weight,omitempty"`
+	Width        *float64 `json:"width,omitempty"`
+	Height       *float64 `json:"height,omitempty"`
+	Length       *float64 `json:"length,omitempty"`
+}
+
+type OrderTotals struct {
+	Subtotal Money             `json:"subtotal"`
+	Extras   []OrderTotalExtra `json:"extras"`
+	Total    Money             `json:"total"`
+}
+
+// OrderTotalExtra is a line added on top of the subtotal, such as tax,
+// shipping or a discount.
+type OrderTotalExtra struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Price       Money  `json:"price"`
+}
+
+type OrderStripeDetails struct {
+	CustomerID      string `json:"customerId"`
+	PaymentIntentID string `json:"paymentIntentId"`
+	ChargeID        string `json:"chargeId"`
+	RefundID        string `json:"refundId"`
+	RefundReason    string `json:"refundReason"`
+	DisputeID       string `json:"disputeId"`
+}
+
+type OrderListParams struct {
+	PaginationParams
+	// Status filters orders by one of the OrderStatus values.
+	Status string
+}
+
+func (p OrderListParams) Query() url.Values {
+	query := p.PaginationParams.Query()
+	if p.Status != "" {
+		query.Set("status", p.Status)
+	}
+
+	return query
+}
+
+type UpdateOrderRequest struct {
+	Comment          string `json:"comment,omitempty"`
+	ShippingProvider string `json:"shippingProvider,omitempty"`
+	ShippingTracking string `json:"shippingTracking,omitempty"`
+}
+
+func (r UpdateOrderRequest) MarshalJSON() ([]byte, error) {
+	type fields UpdateOrderRequest
+	return json.Marshal(fieldsEnvelope{Fields: fields(r)})
+}
+
+type FulfillOrderRequest struct {
+	SendOrderFulfilledEmail bool `json:"sendOrderFulfilledEmail"`
+}
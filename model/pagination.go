@@ -0,0 +1,25 @@
+package model
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// PaginationParams selects a page of a list endpoint. Zero values are left
+// out of the request so the API defaults apply.
+type PaginationParams struct {
+	Offset int
+	Limit  int
+}
+
+func (p PaginationParams) Query() url.Values {
+	query := url.Values{}
+	if p.Offset > 0 {
+		query.Set("offset", strconv.Itoa(p.Offset))
+	}
+	if p.Limit > 0 {
+		query.Set("limit", strconv.Itoa(p.Limit))
+	}
+
+	return query
+}
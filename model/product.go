@@ -0,0 +1,200 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Product struct {
+	ID            string        `json:"_id"`
+	CollectionID  string        `json:"_cid"`
+	Archived      bool          `json:"_archived"`
+	Draft         bool          `json:"_draft"`
+	Name          string        `json:"name"`
+	Slug          string        `json:"slug"`
+	Description   string        `json:"description,omitempty"`
+	Shippable     bool          `json:"shippable"`
+	TaxCategory   string        `json:"tax-category,omitempty"`
+	DefaultSKU    string        `json:"default-sku"`
+	ProductType   string        `json:"ec-product-type,omitempty"`
+	SKUProperties []SKUProperty `json:"sku-properties,omitempty"`
+	CreatedOn     *time.Time    `json:"created-on,omitempty"`
+	UpdatedOn     *time.Time    `json:"updated-on,omitempty"`
+	PublishedOn   *time.Time    `json:"published-on,omitempty"`
+}
+
+type SKUProperty struct {
+	ID   string            `json:"id"`
+	Name string            `json:"name"`
+	Slug string            `json:"slug,omitempty"`
+	Enum []SKUPropertyEnum `json:"enum"`
+}
+
+type SKUPropertyEnum struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug,omitempty"`
+}
+
+type SKU struct {
+	ID             string            `json:"_id"`
+	CollectionID   string            `json:"_cid"`
+	Archived       bool              `json:"_archived"`
+	Draft          bool              `json:"_draft"`
+	Name           string            `json:"name"`
+	Slug           string            `json:"slug"`
+	Product        string            `json:"product"`
+	SKUValues      map[string]string `json:"sku-values,omitempty"`
+	Price          Money             `json:"price"`
+	CompareAtPrice *Money            `json:"compare-at-price,omitempty"`
+	MainImage      *Image            `json:"main-image,omitempty"`
+	MoreImages     []Image           `json:"more-images,omitempty"`
+	SKU            string            `json:"sku,omitempty"`
+	Width          *float64          `json:"width,omitempty"`
+	Length         *float64          `json:"length,omitempty"`
+	Height         *float64          `json:"height,omitempty"`
+	Weight         *float64          `json:"weight,omitempty"`
+	CreatedOn      *time.Time        `json:"created-on,omitempty"`
+	UpdatedOn      *time.Time        `json:"updated-on,omitempty"`
+	PublishedOn    *time.Time        `json:"published-on,omitempty"`
+}
+
+type Image struct {
+	FileID string `json:"fileId,omitempty"`
+	URL    string `json:"url"`
+	Alt    string `json:"alt,omitempty"`
+}
+
+// Amounts returns pointers to every money value of the SKU.
+func (s *SKU) Amounts() []*Money {
+	return []*Money{&s.Price, s.CompareAtPrice}
+}
+
+// ProductFields are the fields of a product to create or change. Fields left
+// nil or empty are not sent, so an update keeps their current value.
+type ProductFields struct {
+	Archived      *bool         `json:"_archived,omitempty"`
+	Draft         *bool         `json:"_draft,omitempty"`
+	Name          string        `json:"name,omitempty"`
+	Slug          string        `json:"slug,omitempty"`
+	Description   string        `json:"description,omitempty"`
+	Shippable     *bool         `json:"shippable,omitempty"`
+	TaxCategory   string        `json:"tax-category,omitempty"`
+	ProductType   string        `json:"ec-product-type,omitempty"`
+	SKUProperties []SKUProperty `json:"sku-properties,omitempty"`
+}
+
+// SKUFields are the fields of a SKU to create or change. Fields left nil or
+// empty are not sent, so an update keeps their current value.
+type SKUFields struct {
+	Archived       *bool             `json:"_archived,omitempty"`
+	Draft          *bool             `json:"_draft,omitempty"`
+	Name           string            `json:"name,omitempty"`
+	Slug           string            `json:"slug,omitempty"`
+	SKUValues      map[string]string `json:"sku-values,omitempty"`
+	Price          *Money            `json:"price,omitempty"`
+	CompareAtPrice *Money            `json:"compare-at-price,omitempty"`
+	MainImage      *Image            `json:"main-image,omitempty"`
+	MoreImages     []Image           `json:"more-images,omitempty"`
+	SKU            string            `json:"sku,omitempty"`
+	Width          *float64          `json:"width,omitempty"`
+	Length         *float64          `json:"length,omitempty"`
+	Height         *float64          `json:"height,omitempty"`
+	Weight         *float64          `json:"weight,omitempty"`
+}
+
+type fieldsEnvelope struct {
+	Fields interface{} `json:"fields"`
+}
+
+type CreateProductRequest struct {
+	Product ProductFields
+	SKU     SKUFields
+}
+
+// MarshalJSON sends _archived and _draft as false when they are not set,
+// since the API requires them to create an item.
+func (r CreateProductRequest) MarshalJSON() ([]byte, error) {
+	product, sku := r.Product, r.SKU
+	product.Archived, product.Draft = orFalse(product.Archived), orFalse(product.Draft)
+	sku.Archived, sku.Draft = orFalse(sku.Archived), orFalse(sku.Draft)
+
+	return json.Marshal(struct {
+		Product fieldsEnvelope `json:"product"`
+		SKU     fieldsEnvelope `json:"sku"`
+	}{
+		Product: fieldsEnvelope{Fields: product},
+		SKU:     fieldsEnvelope{Fields: sku},
+	})
+}
+
+func orFalse(b *bool) *bool {
+	if b != nil {
+		return b
+	}
+
+	f := false
+	return &f
+}
+
+type UpdateProductRequest struct {
+	Product ProductFields
+}
+
+func (r UpdateProductRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fieldsEnvelope{Fields: r.Product})
+}
+
+type ProductWithDefaultSKU struct {
+	Product Product `json:"product"`
+	SKU     SKU     `json:"sku"`
+}
+
+type ProductWithSKUs struct {
+	Product Product `json:"product"`
+	SKUs    []SKU   `json:"skus"`
+}
+
+type ProductList struct {
+	Items  []ProductWithSKUs `json:"items"`
+	Count  int               `json:"count"`
+	Limit  int               `json:"limit"`
+	Offset int               `json:"offset"`
+	Total  int               `json:"total"`
+}
+
+// CreateSKUsRequest sends _archived and _draft as false when they are not
+// set, like CreateProductRequest.
+type CreateSKUsRequest struct {
+	SKUs []SKUFields
+}
+
+func (r CreateSKUsRequest) MarshalJSON() ([]byte, error) {
+	skus := make([]fieldsEnvelope, len(r.SKUs))
+	for i, sku := range r.SKUs {
+		sku.Archived, sku.Draft = orFalse(sku.Archived), orFalse(sku.Draft)
+		skus[i] = fieldsEnvelope{Fields: sku}
+	}
+
+	return json.Marshal(struct {
+		SKUs []fieldsEnvelope `json:"skus"`
+	}{
+		SKUs: skus,
+	})
+}
+
+type UpdateSKURequest struct {
+	SKU SKUFields
+}
+
+func (r UpdateSKURequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		SKU fieldsEnvelope `json:"sku"`
+	}{
+		SKU: fieldsEnvelope{Fields: r.SKU},
+	})
+}
+
+type SKUList struct {
+	SKUs []SKU `json:"skus"`
+}
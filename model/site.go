@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+type Site struct {
+	ID            string    `json:"_id"`
+	CreatedOn     time.Time `json:"createdOn"`
+	Name          string    `json:"name"`
+	ShortName     string    `json:"shortName"`
+	LastPublished time.Time `json:"lastPublished"`
+	PreviewURL    string    `json:"previewUrl"`
+	Timezone      string    `json:"timezone"`
+	Database      string    `json:"database"`
+}
+
+type PublishSiteRequest struct {
+	Domains []string `json:"domains"`
+}
+
+type PublishSiteResponse struct {
+	Queued bool `json:"queued"`
+}
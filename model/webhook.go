@@ -0,0 +1,69 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	TriggerFormSubmission        string = "form_submission"
+	TriggerSitePublish           string = "site_publish"
+	TriggerEcommNewOrder         string = "ecomm_new_order"
+	TriggerEcommOrderChanged     string = "ecomm_order_changed"
+	TriggerEcommInventoryChanged string = "ecomm_inventory_changed"
+	TriggerCollectionItemCreated string = "collection_item_created"
+	TriggerCollectionItemChanged string = "collection_item_changed"
+	TriggerCollectionItemDeleted string = "collection_item_deleted"
+)
+
+// WebhookEvent is a single webhook delivery received from Webflow. Payload
+// holds the raw JSON body exactly as it was delivered.
+type WebhookEvent struct {
+	ID          string          `json:"id"`
+	TriggerType string          `json:"triggerType"`
+	Payload     json.RawMessage `json:"payload"`
+	ReceivedAt  time.Time       `json:"receivedAt"`
+}
+
+type FormSubmission struct {
+	ID   string                 `json:"_id"`
+	Name string                 `json:"name"`
+	Site string                 `json:"site"`
+	Data map[string]interface{} `json:"data"`
+	Date time.Time              `json:"d"`
+}
+
+type SitePublish struct {
+	Site        string            `json:"site"`
+	PublishTime int64             `json:"publishTime"`
+	Domains     []string          `json:"domains"`
+	PublishedBy SitePublishAuthor `json:"publishedBy"`
+}
+
+type SitePublishAuthor struct {
+	Name string `json:"name"`
+}
+
+type InventoryChanged struct {
+	ID            string `json:"_id"`
+	OrderLimit    int    `json:"orderLimit"`
+	Quantity      int    `json:"quantity"`
+	InventoryType string `json:"inventoryType"`
+}
+
+type CollectionItemDeleted struct {
+	Deleted int    `json:"deleted"`
+	ItemID  string `json:"itemId"`
+}
+
+// Webhook is a webhook registered on a site.
+type Webhook struct {
+	ID          string                 `json:"_id"`
+	TriggerType string                 `json:"triggerType"`
+	TriggerID   string                 `json:"triggerId"`
+	Site        string                 `json:"site"`
+	URL         string                 `json:"url"`
+	Filter      map[string]interface{} `json:"filter,omitempty"`
+	LastUsed    *time.Time             `json:"lastUsed,omitempty"`
+	CreatedOn   *time.Time             `json:"createdOn,omitempty"`
+}
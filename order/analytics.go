@@ -0,0 +1,247 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+)
+
+const (
+	PeriodDay   string = "day"
+	PeriodWeek  string = "week"
+	PeriodMonth string = "month"
+)
+
+type ReportOptions struct {
+	// Period is PeriodDay, PeriodWeek or PeriodMonth, PeriodDay when empty.
+	// Weeks start on Monday.
+	Period string
+	// From and To bound the report, To being exclusive. Both are required.
+	From time.Time
+	To   time.Time
+	// Location is used to cut periods, UTC when nil.
+	Location *time.Location
+	// TopProducts is the number of products ranked per period, 5 when zero.
+	TopProducts int
+	// Currency resolves the site currency. When nil the currency of the
+	// first order in range with one is used. Amounts the API returned
+	// without a unit are taken to be in the report currency. Orders in
+	// another currency are skipped and counted in Report.Skipped.
+	Currency common.CurrencySource
+	PageSize int
+}
+
+type ProductSales struct {
+	ProductID   string
+	ProductName string
+	Quantity    int
+	Revenue     model.Money
+}
+
+// PeriodStats aggregates the orders accepted in a period. Refunds are
+// counted in the period the order was refunded in.
+type PeriodStats struct {
+	Start             time.Time
+	Orders            int
+	Revenue           model.Money
+	AverageOrderValue model.Money
+	Refunds           int
+	RefundedAmount    model.Money
+	TopProducts       []ProductSales
+
+	products map[string]*ProductSales
+}
+
+type Report struct {
+	Currency string
+	// Periods holds one entry per period between From and To, including
+	// periods without orders.
+	Periods []PeriodStats
+	Total   PeriodStats
+	Skipped int
+}
+
+// Analyze streams the orders of a site page by page and aggregates them per
+// period.
+func Analyze(ctx context.Context, service Order, siteID string, opts ReportOptions) (*Report, *common.Error) {
+	if opts.From.IsZero() || opts.To.IsZero() || !opts.From.Before(opts.To) {
+		return nil, common.FromGoErr(fmt.Errorf("order: invalid report range %s to %s", opts.From, opts.To))
+	}
+	if opts.Period == "" {
+		opts.Period = PeriodDay
+	}
+	if opts.Period != PeriodDay && opts.Period != PeriodWeek && opts.Period != PeriodMonth {
+		return nil, common.FromGoErr(fmt.Errorf("order: unknown report period %q", opts.Period))
+	}
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	if opts.TopProducts <= 0 {
+		opts.TopProducts = 5
+	}
+
+	report := &Report{}
+	if opts.Currency != nil {
+		unit, err := opts.Currency.DefaultCurrency(ctx, siteID)
+		if err != nil {
+			return nil, err
+		}
+		report.Currency = unit
+	}
+
+	periods := map[time.Time]*PeriodStats{}
+	var starts []time.Time
+	for start := periodStart(opts.From, opts.Period, opts.Location); start.Before(opts.To); start = nextPeriod(start, opts.Period) {
+		periods[start] = newPeriodStats(start)
+		starts = append(starts, start)
+	}
+	total := newPeriodStats(starts[0])
+
+	inRange := func(t *time.Time) bool {
+		return t != nil && !t.Before(opts.From) && t.Before(opts.To)
+	}
+
+	add := func(order model.Order, accepted bool, refunded bool) {
+		model.DefaultCurrency(report.Currency, order.Amounts()...)
+		if order.Totals.Total.Unit != report.Currency {
+			report.Skipped++
+			return
+		}
+
+		if accepted {
+			stats := periods[periodStart(*order.AcceptedOn, opts.Period, opts.Location)]
+			stats.addOrder(order)
+			total.addOrder(order)
+		}
+		if refunded {
+			stats := periods[periodStart(*order.RefundedOn, opts.Period, opts.Location)]
+			stats.addRefund(order)
+			total.addRefund(order)
+		}
+	}
+
+	// Orders without a unit seen before the currency is known wait for it.
+	var pending []model.Order
+	params := model.OrderListParams{PaginationParams: model.PaginationParams{Limit: opts.PageSize}}
+	err := ForEach(ctx, service, siteID, params, func(order model.Order) error {
+		accepted := inRange(order.AcceptedOn)
+		refunded := order.Status == model.OrderStatusRefunded && inRange(order.RefundedOn)
+		if !accepted && !refunded {
+			return nil
+		}
+
+		if report.Currency == "" {
+			if order.Totals.Total.Unit == "" {
+				pending = append(pending, order)
+				return nil
+			}
+			report.Currency = order.Totals.Total.Unit
+		}
+		add(order, accepted, refunded)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, order := range pending {
+		add(order, inRange(order.AcceptedOn), order.Status == model.OrderStatusRefunded && inRange(order.RefundedOn))
+	}
+
+	for _, start := range starts {
+		stats := periods[start]
+		stats.finish(report.Currency, opts.TopProducts)
+		report.Periods = append(report.Periods, *stats)
+	}
+	total.finish(report.Currency, opts.TopProducts)
+	report.Total = *total
+
+	return report, nil
+}
+
+func newPeriodStats(start time.Time) *PeriodStats {
+	return &PeriodStats{
+		Start:    start,
+		products: map[string]*ProductSales{},
+	}
+}
+
+// The currency of every order was checked by Analyze, so the additions
+// below cannot fail.
+func (s *PeriodStats) addOrder(order model.Order) {
+	s.Orders++
+	s.Revenue, _ = s.Revenue.Add(order.Totals.Total)
+
+	for _, item := range order.PurchasedItems {
+		sales, ok := s.products[item.ProductID]
+		if !ok {
+			sales = &ProductSales{ProductID: item.ProductID, ProductName: item.ProductName}
+			s.products[item.ProductID] = sales
+		}
+		sales.Quantity += item.Count
+		if revenue, err := sales.Revenue.Add(item.RowTotal); err == nil {
+			sales.Revenue = revenue
+		}
+	}
+}
+
+func (s *PeriodStats) addRefund(order model.Order) {
+	s.Refunds++
+	s.RefundedAmount, _ = s.RefundedAmount.Add(order.Totals.Total)
+}
+
+func (s *PeriodStats) finish(currency string, top int) {
+	model.DefaultCurrency(currency, &s.Revenue, &s.RefundedAmount)
+	s.AverageOrderValue = model.Money{Unit: currency}
+	if s.Orders > 0 {
+		s.AverageOrderValue.Value = s.Revenue.Value / int64(s.Orders)
+	}
+
+	s.TopProducts = make([]ProductSales, 0, len(s.products))
+	for _, sales := range s.products {
+		model.DefaultCurrency(currency, &sales.Revenue)
+		s.TopProducts = append(s.TopProducts, *sales)
+	}
+	sort.Slice(s.TopProducts, func(i, j int) bool {
+		a, b := s.TopProducts[i], s.TopProducts[j]
+		if a.Revenue.Value != b.Revenue.Value {
+			return a.Revenue.Value > b.Revenue.Value
+		}
+		if a.Quantity != b.Quantity {
+			return a.Quantity > b.Quantity
+		}
+		return a.ProductID < b.ProductID
+	})
+	if len(s.TopProducts) > top {
+		s.TopProducts = s.TopProducts[:top]
+	}
+	s.products = nil
+}
+
+func periodStart(t time.Time, period string, loc *time.Location) time.Time {
+	t = t.In(loc)
+	switch period {
+	case PeriodWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case PeriodMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	}
+}
+
+func nextPeriod(start time.Time, period string) time.Time {
+	switch period {
+	case PeriodWeek:
+		return start.AddDate(0, 0, 7)
+	case PeriodMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
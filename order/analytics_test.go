@@ -0,0 +1,168 @@
+package order_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/nasrul21/go-webflow/order"
+	"github.com/stretchr/testify/assert"
+)
+
+func analyticsOrder(id string, status string, acceptedOn time.Time, total int64, items ...model.OrderPurchasedItem) model.Order {
+	o := model.Order{
+		OrderID:        id,
+		Status:         status,
+		AcceptedOn:     &acceptedOn,
+		PurchasedItems: items,
+	}
+	o.Totals.Total = model.NewMoney(total, "USD")
+
+	return o
+}
+
+func analyticsItem(productID string, count int, rowTotal int64) model.OrderPurchasedItem {
+	return model.OrderPurchasedItem{
+		ProductID:   productID,
+		ProductName: "Product " + productID,
+		Count:       count,
+		RowTotal:    model.NewMoney(rowTotal, "USD"),
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2022, 3, d, 10, 0, 0, 0, time.UTC)
+	}
+
+	refunded := analyticsOrder("o4", model.OrderStatusRefunded, day(2), 1500, analyticsItem("mug", 1, 1500))
+	refundedOn := day(8)
+	refunded.RefundedOn = &refundedOn
+
+	euro := analyticsOrder("o5", model.OrderStatusFulfilled, day(3), 1000)
+	euro.Totals.Total = model.NewMoney(1000, "EUR")
+
+	orders := []model.Order{
+		analyticsOrder("o1", model.OrderStatusFulfilled, day(1), 3000, analyticsItem("shirt", 1, 2000), analyticsItem("mug", 1, 1000)),
+		analyticsOrder("o2", model.OrderStatusUnfulfilled, day(2), 2000, analyticsItem("shirt", 1, 2000)),
+		analyticsOrder("o3", model.OrderStatusFulfilled, day(9), 4000, analyticsItem("poster", 2, 4000)),
+		refunded,
+		euro,
+		analyticsOrder("o6", model.OrderStatusFulfilled, day(20), 9900),
+	}
+	service := &fakeOrderList{orders: orders}
+
+	report, err := order.Analyze(context.Background(), service, siteID, order.ReportOptions{
+		Period:      order.PeriodWeek,
+		From:        time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:          time.Date(2022, 3, 15, 0, 0, 0, 0, time.UTC),
+		TopProducts: 2,
+		Currency:    currencySource{siteID: "USD"},
+		PageSize:    2,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "USD", report.Currency)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Len(t, service.calls, 4)
+
+	assert.Len(t, report.Periods, 3)
+	assert.Equal(t, time.Date(2022, 2, 28, 0, 0, 0, 0, time.UTC), report.Periods[0].Start)
+
+	first := report.Periods[0]
+	assert.Equal(t, 3, first.Orders)
+	assert.Equal(t, model.NewMoney(6500, "USD"), first.Revenue)
+	assert.Equal(t, model.NewMoney(2166, "USD"), first.AverageOrderValue)
+	assert.Equal(t, 0, first.Refunds)
+	assert.Equal(t, []string{"shirt", "mug"}, []string{first.TopProducts[0].ProductID, first.TopProducts[1].ProductID})
+	assert.Equal(t, 2, first.TopProducts[0].Quantity)
+	assert.Equal(t, model.NewMoney(2500, "USD"), first.TopProducts[1].Revenue)
+
+	second := report.Periods[1]
+	assert.Equal(t, 1, second.Orders)
+	assert.Equal(t, 1, second.Refunds)
+	assert.Equal(t, model.NewMoney(1500, "USD"), second.RefundedAmount)
+
+	assert.Equal(t, 0, report.Periods[2].Orders)
+	assert.Equal(t, model.NewMoney(0, "USD"), report.Periods[2].Revenue)
+
+	assert.Equal(t, 4, report.Total.Orders)
+	assert.Equal(t, model.NewMoney(10500, "USD"), report.Total.Revenue)
+	assert.Equal(t, "poster", report.Total.TopProducts[0].ProductID)
+}
+
+func TestAnalyzeInvalidOptions(t *testing.T) {
+	from := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := order.Analyze(context.Background(), &fakeOrderList{}, siteID, order.ReportOptions{From: from})
+	assert.NotNil(t, err)
+
+	_, err = order.Analyze(context.Background(), &fakeOrderList{}, siteID, order.ReportOptions{From: from, To: from.AddDate(0, 1, 0), Period: "year"})
+	assert.NotNil(t, err)
+}
+
+func TestAnalyzeMissingCurrency(t *testing.T) {
+	day := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	unitless := func(id string, total int64) model.Order {
+		o := analyticsOrder(id, model.OrderStatusFulfilled, day, total, analyticsItem("mug", 1, total))
+		o.Totals.Total.Unit = ""
+		o.PurchasedItems[0].RowTotal.Unit = ""
+		return o
+	}
+
+	testcases := []struct {
+		desc             string
+		orders           []model.Order
+		currency         common.CurrencySource
+		expectedCurrency string
+		expectedRevenue  int64
+		expectedSkipped  int
+	}{
+		{
+			desc:             "should use currency source for orders without unit",
+			orders:           []model.Order{unitless("o1", 1000), unitless("o2", 2000)},
+			currency:         currencySource{siteID: "USD"},
+			expectedCurrency: "USD",
+			expectedRevenue:  3000,
+		},
+		{
+			desc: "should use first order currency for orders without unit",
+			orders: []model.Order{
+				unitless("o1", 1000),
+				analyticsOrder("o2", model.OrderStatusFulfilled, day, 2000),
+				unitless("o3", 500),
+			},
+			expectedCurrency: "USD",
+			expectedRevenue:  3500,
+		},
+		{
+			desc: "should skip orders in another currency",
+			orders: []model.Order{
+				unitless("o1", 1000),
+				analyticsOrder("o2", model.OrderStatusFulfilled, day, 2000),
+			},
+			currency:         currencySource{siteID: "EUR"},
+			expectedCurrency: "EUR",
+			expectedRevenue:  1000,
+			expectedSkipped:  1,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			report, err := order.Analyze(context.Background(), &fakeOrderList{orders: tc.orders}, siteID, order.ReportOptions{
+				From:     time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC),
+				To:       time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC),
+				Currency: tc.currency,
+			})
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedCurrency, report.Currency)
+			assert.Equal(t, model.NewMoney(tc.expectedRevenue, tc.expectedCurrency), report.Total.Revenue)
+			assert.Equal(t, tc.expectedSkipped, report.Skipped)
+			assert.Equal(t, tc.expectedCurrency, report.Total.TopProducts[0].Revenue.Unit)
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package order
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+)
+
+const (
+	FormatCSV   string = "csv"
+	FormatJSONL string = "jsonl"
+)
+
+// maxPageSize is the largest page the API returns.
+const maxPageSize = 100
+
+// ForEach calls fn for every order matching params, fetching pages of
+// params.Limit orders (100 when zero or above 100, the API maximum) until the
+// list is exhausted. It stops at the first error returned by fn.
+func ForEach(ctx context.Context, service Order, siteID string, params model.OrderListParams, fn func(order model.Order) error) *common.Error {
+	if params.Limit <= 0 || params.Limit > maxPageSize {
+		params.Limit = maxPageSize
+	}
+
+	for {
+		orders, err := service.GetListWithContext(ctx, siteID, params)
+		if err != nil {
+			return err
+		}
+
+		for _, order := range orders {
+			if err := fn(order); err != nil {
+				return common.FromGoErr(err)
+			}
+		}
+
+		if len(orders) < params.Limit {
+			return nil
+		}
+		params.Offset += len(orders)
+	}
+}
+
+type ExportOptions struct {
+	// Format is FormatCSV or FormatJSONL, FormatCSV when empty.
+	Format string
+	// Since skips orders accepted before it when set. The API cannot filter
+	// by date, so the filter is applied client-side and every order is still
+	// listed.
+	Since time.Time
+	// Status only exports orders with one of the OrderStatus values when set.
+	Status   string
+	PageSize int
+}
+
+// ExportRow is one purchased item of an order flattened together with the
+// order details. Orders without items export a single row with empty item
+// columns. Amounts are decimals in the order currency.
+type ExportRow struct {
+	OrderID            string `json:"order_id"`
+	Status             string `json:"status"`
+	AcceptedOn         string `json:"accepted_on"`
+	FulfilledOn        string `json:"fulfilled_on"`
+	RefundedOn         string `json:"refunded_on"`
+	CustomerName       string `json:"customer_name"`
+	CustomerEmail      string `json:"customer_email"`
+	Currency           string `json:"currency"`
+	Subtotal           string `json:"subtotal"`
+	Tax                string `json:"tax"`
+	Shipping           string `json:"shipping"`
+	Discount           string `json:"discount"`
+	Total              string `json:"total"`
+	NetAmount          string `json:"net_amount"`
+	PaymentProcessor   string `json:"payment_processor"`
+	ShippingProvider   string `json:"shipping_provider"`
+	ShippingTracking   string `json:"shipping_tracking"`
+	ShippingAddressee  string `json:"shipping_addressee"`
+	ShippingLine1      string `json:"shipping_line1"`
+	ShippingLine2      string `json:"shipping_line2"`
+	ShippingCity       string `json:"shipping_city"`
+	ShippingState      string `json:"shipping_state"`
+	ShippingPostalCode string `json:"shipping_postal_code"`
+	ShippingCountry    string `json:"shipping_country"`
+	BillingAddressee   string `json:"billing_addressee"`
+	BillingLine1       string `json:"billing_line1"`
+	BillingLine2       string `json:"billing_line2"`
+	BillingCity        string `json:"billing_city"`
+	BillingState       string `json:"billing_state"`
+	BillingPostalCode  string `json:"billing_postal_code"`
+	BillingCountry     string `json:"billing_country"`
+	ItemProductID      string `json:"item_product_id"`
+	ItemProductName    string `json:"item_product_name"`
+	ItemVariantID      string `json:"item_variant_id"`
+	ItemVariantName    string `json:"item_variant_name"`
+	ItemSKU            string `json:"item_sku"`
+	ItemCount          string `json:"item_count"`
+	ItemUnitPrice      string `json:"item_unit_price"`
+	ItemRowTotal       string `json:"item_row_total"`
+}
+
+// ExportColumns is the CSV header, in the order ExportRow.Values returns
+// the values.
+var ExportColumns = []string{
+	"order_id", "status", "accepted_on", "fulfilled_on", "refunded_on",
+	"customer_name", "customer_email", "currency",
+	"subtotal", "tax", "shipping", "discount", "total", "net_amount",
+	"payment_processor", "shipping_provider", "shipping_tracking",
+	"shipping_addressee", "shipping_line1", "shipping_line2", "shipping_city",
+	"shipping_state", "shipping_postal_code", "shipping_country",
+	"billing_addressee", "billing_line1", "billing_line2", "billing_city",
+	"billing_state", "billing_postal_code", "billing_country",
+	"item_product_id", "item_product_name", "item_variant_id", "item_variant_name",
+	"item_sku", "item_count", "item_unit_price", "item_row_total",
+}
+
+func (r ExportRow) Values() []string {
+	return []string{
+		r.OrderID, r.Status, r.AcceptedOn, r.FulfilledOn, r.RefundedOn,
+		r.CustomerName, r.CustomerEmail, r.Currency,
+		r.Subtotal, r.Tax, r.Shipping, r.Discount, r.Total, r.NetAmount,
+		r.PaymentProcessor, r.ShippingProvider, r.ShippingTracking,
+		r.ShippingAddressee, r.ShippingLine1, r.ShippingLine2, r.ShippingCity,
+		r.ShippingState, r.ShippingPostalCode, r.ShippingCountry,
+		r.BillingAddressee, r.BillingLine1, r.BillingLine2, r.BillingCity,
+		r.BillingState, r.BillingPostalCode, r.BillingCountry,
+		r.ItemProductID, r.ItemProductName, r.ItemVariantID, r.ItemVariantName,
+		r.ItemSKU, r.ItemCount, r.ItemUnitPrice, r.ItemRowTotal,
+	}
+}
+
+// Flatten returns the export rows of an order, one per purchased item.
+func Flatten(order model.Order) []ExportRow {
+	base := ExportRow{
+		OrderID:          order.OrderID,
+		Status:           order.Status,
+		AcceptedOn:       formatTime(order.AcceptedOn),
+		FulfilledOn:      formatTime(order.FulfilledOn),
+		RefundedOn:       formatTime(order.RefundedOn),
+		CustomerName:     order.CustomerInfo.FullName,
+		CustomerEmail:    order.CustomerInfo.Email,
+		Currency:         order.Totals.Total.Unit,
+		Subtotal:         order.Totals.Subtotal.Amount(),
+		Tax:              extrasAmount(order, "tax"),
+		Shipping:         extrasAmount(order, "shipping"),
+		Discount:         extrasAmount(order, "discount"),
+		Total:            order.Totals.Total.Amount(),
+		NetAmount:        order.NetAmount.Amount(),
+		PaymentProcessor: order.PaymentProcessor,
+		ShippingProvider: order.ShippingProvider,
+		ShippingTracking: order.ShippingTracking,
+	}
+
+	if a := order.ShippingAddress; a != nil {
+		base.ShippingAddressee = a.Addressee
+		base.ShippingLine1 = a.Line1
+		base.ShippingLine2 = a.Line2
+		base.ShippingCity = a.City
+		base.ShippingState = a.State
+		base.ShippingPostalCode = a.PostalCode
+		base.ShippingCountry = a.Country
+	}
+	if a := order.BillingAddress; a != nil {
+		base.BillingAddressee = a.Addressee
+		base.BillingLine1 = a.Line1
+		base.BillingLine2 = a.Line2
+		base.BillingCity = a.City
+		base.BillingState = a.State
+		base.BillingPostalCode = a.PostalCode
+		base.BillingCountry = a.Country
+	}
+
+	if len(order.PurchasedItems) == 0 {
+		return []ExportRow{base}
+	}
+
+	rows := make([]ExportRow, len(order.PurchasedItems))
+	for i, item := range order.PurchasedItems {
+		row := base
+		row.ItemProductID = item.ProductID
+		row.ItemProductName = item.ProductName
+		row.ItemVariantID = item.VariantID
+		row.ItemVariantName = item.VariantName
+		row.ItemSKU = item.VariantSKU
+		row.ItemCount = strconv.Itoa(item.Count)
+		row.ItemUnitPrice = item.VariantPrice.Amount()
+		row.ItemRowTotal = item.RowTotal.Amount()
+		rows[i] = row
+	}
+
+	return rows
+}
+
+// Export writes every order of a site to w and returns the number of
+// orders exported. In CSV exports, customer supplied text starting with a
+// character spreadsheets read as a formula is prefixed with a quote.
+func Export(ctx context.Context, service Order, siteID string, w io.Writer, opts ExportOptions) (int, *common.Error) {
+	var write func(row ExportRow) error
+	var flush func() error
+
+	switch opts.Format {
+	case "", FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(ExportColumns); err != nil {
+			return 0, common.FromGoErr(err)
+		}
+		write = func(row ExportRow) error {
+			return writer.Write(escapeFormulas(row).Values())
+		}
+		flush = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	case FormatJSONL:
+		encoder := json.NewEncoder(w)
+		write = func(row ExportRow) error {
+			return encoder.Encode(row)
+		}
+		flush = func() error {
+			return nil
+		}
+	default:
+		return 0, common.FromGoErr(fmt.Errorf("order: unknown export format %q", opts.Format))
+	}
+
+	params := model.OrderListParams{
+		PaginationParams: model.PaginationParams{Limit: opts.PageSize},
+		Status:           opts.Status,
+	}
+
+	count := 0
+	err := ForEach(ctx, service, siteID, params, func(order model.Order) error {
+		if !opts.Since.IsZero() && (order.AcceptedOn == nil || order.AcceptedOn.Before(opts.Since)) {
+			return nil
+		}
+
+		for _, row := range Flatten(order) {
+			if err := write(row); err != nil {
+				return err
+			}
+		}
+		count++
+
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+
+	if err := flush(); err != nil {
+		return count, common.FromGoErr(err)
+	}
+
+	return count, nil
+}
+
+// escapeFormulas neutralises the free text columns of row, which customers
+// control, so spreadsheets opening the CSV do not evaluate them. Amounts are
+// left alone since negative values are legitimate.
+func escapeFormulas(row ExportRow) ExportRow {
+	for _, field := range []*string{
+		&row.CustomerName, &row.CustomerEmail,
+		&row.ShippingAddressee, &row.ShippingLine1, &row.ShippingLine2, &row.ShippingCity,
+		&row.ShippingState, &row.ShippingPostalCode, &row.ShippingCountry,
+		&row.BillingAddressee, &row.BillingLine1, &row.BillingLine2, &row.BillingCity,
+		&row.BillingState, &row.BillingPostalCode, &row.BillingCountry,
+		&row.ItemProductName, &row.ItemVariantName,
+	} {
+		if *field != "" && strings.ContainsRune("=+-@\t\r", rune((*field)[0])) {
+			*field = "'" + *field
+		}
+	}
+
+	return row
+}
+
+func extrasAmount(order model.Order, extraType string) string {
+	total := model.Money{Unit: order.Totals.Total.Unit}
+	for _, extra := range order.Totals.Extras {
+		if extra.Type != extraType {
+			continue
+		}
+
+		// Extras always share the order currency; skip a malformed one
+		// rather than failing the whole export.
+		if sum, err := total.Add(extra.Price); err == nil {
+			total = sum
+		}
+	}
+
+	return total.Amount()
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,197 @@
+package order_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/nasrul21/go-webflow/order"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeOrderList struct {
+	order.Order
+	orders []model.Order
+	calls  []model.OrderListParams
+}
+
+func (f *fakeOrderList) GetListWithContext(ctx context.Context, siteID string, params model.OrderListParams) ([]model.Order, *common.Error) {
+	f.calls = append(f.calls, params)
+
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+	if params.Offset >= len(f.orders) {
+		return []model.Order{}, nil
+	}
+	end := params.Offset + params.Limit
+	if end > len(f.orders) {
+		end = len(f.orders)
+	}
+
+	return f.orders[params.Offset:end], nil
+}
+
+func exportOrders() []model.Order {
+	first := *expectedOrder()
+
+	second := *expectedOrder()
+	second.OrderID = "ab1-2cd"
+	second.PurchasedItems = nil
+	acceptedOn := time.Date(2018, 11, 1, 0, 0, 0, 0, time.UTC)
+	second.AcceptedOn = &acceptedOn
+
+	third := *expectedOrder()
+	third.OrderID = "ef3-4gh"
+	third.PurchasedItems = append(third.PurchasedItems, model.OrderPurchasedItem{
+		Count:        2,
+		ProductID:    "5eb9fd05caef491eb9757190",
+		ProductName:  "Black Cup",
+		VariantSKU:   "cup-black",
+		VariantPrice: model.NewMoney(1000, "USD"),
+		RowTotal:     model.NewMoney(2000, "USD"),
+	})
+
+	return []model.Order{first, second, third}
+}
+
+func TestForEach(t *testing.T) {
+	service := &fakeOrderList{orders: exportOrders()}
+
+	var ids []string
+	err := order.ForEach(context.Background(), service, siteID, model.OrderListParams{
+		PaginationParams: model.PaginationParams{Limit: 2},
+	}, func(o model.Order) error {
+		ids = append(ids, o.OrderID)
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"dd6-5ca", "ab1-2cd", "ef3-4gh"}, ids)
+	assert.Len(t, service.calls, 2)
+	assert.Equal(t, 2, service.calls[1].Offset)
+}
+
+func TestForEachLimitAboveMax(t *testing.T) {
+	orders := make([]model.Order, 150)
+	for i := range orders {
+		orders[i].OrderID = strconv.Itoa(i)
+	}
+	service := &fakeOrderList{orders: orders}
+
+	count := 0
+	err := order.ForEach(context.Background(), service, siteID, model.OrderListParams{
+		PaginationParams: model.PaginationParams{Limit: 250},
+	}, func(o model.Order) error {
+		count++
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 150, count)
+	assert.Equal(t, 100, service.calls[0].Limit)
+}
+
+func TestFlatten(t *testing.T) {
+	rows := order.Flatten(*expectedOrder())
+
+	assert.Len(t, rows, 1)
+	assert.Equal(t, "dd6-5ca", rows[0].OrderID)
+	assert.Equal(t, "2018-12-03T22:06:15Z", rows[0].AcceptedOn)
+	assert.Equal(t, "USD", rows[0].Currency)
+	assert.Equal(t, "55.00", rows[0].Subtotal)
+	assert.Equal(t, "2.20", rows[0].Tax)
+	assert.Equal(t, "3.79", rows[0].Shipping)
+	assert.Equal(t, "0.00", rows[0].Discount)
+	assert.Equal(t, "60.99", rows[0].Total)
+	assert.Equal(t, "London", rows[0].ShippingCity)
+	assert.Equal(t, "", rows[0].BillingCity)
+	assert.Equal(t, "cup-white-l", rows[0].ItemSKU)
+	assert.Equal(t, "1", rows[0].ItemCount)
+	assert.Len(t, rows[0].Values(), len(order.ExportColumns))
+}
+
+func TestExportCSV(t *testing.T) {
+	service := &fakeOrderList{orders: exportOrders()}
+
+	var buf bytes.Buffer
+	count, err := order.Export(context.Background(), service, siteID, &buf, order.ExportOptions{
+		Since: time.Date(2018, 12, 1, 0, 0, 0, 0, time.UTC),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+
+	records, _ := csv.NewReader(&buf).ReadAll()
+	assert.Len(t, records, 4)
+	assert.Equal(t, order.ExportColumns, records[0])
+	assert.Equal(t, "dd6-5ca", records[1][0])
+	assert.Equal(t, "ef3-4gh", records[3][0])
+	assert.Equal(t, "cup-black", records[3][35])
+	assert.Equal(t, "20.00", records[3][38])
+}
+
+func TestExportCSVEscapesFormulas(t *testing.T) {
+	malicious := *expectedOrder()
+	malicious.CustomerInfo.FullName = "=HYPERLINK(\"http://example.com\")"
+	malicious.CustomerInfo.Email = "@SUM(A1)"
+	malicious.ShippingAddress = &model.OrderAddress{Addressee: "+1 555", Line1: "-2+3", Line2: "\tTab", City: "Berlin"}
+	malicious.PurchasedItems = malicious.PurchasedItems[:1:1]
+	malicious.PurchasedItems[0].ProductName = "\rCup"
+	malicious.Totals.Extras = []model.OrderTotalExtra{{Type: "discount", Price: model.NewMoney(-500, "USD")}}
+	service := &fakeOrderList{orders: []model.Order{malicious}}
+
+	var buf bytes.Buffer
+	_, err := order.Export(context.Background(), service, siteID, &buf, order.ExportOptions{})
+	assert.Nil(t, err)
+
+	records, _ := csv.NewReader(&buf).ReadAll()
+	assert.Len(t, records, 2)
+	assert.Equal(t, "'=HYPERLINK(\"http://example.com\")", records[1][5])
+	assert.Equal(t, "'@SUM(A1)", records[1][6])
+	assert.Equal(t, "-5.00", records[1][11])
+	assert.Equal(t, "'+1 555", records[1][17])
+	assert.Equal(t, "'-2+3", records[1][18])
+	assert.Equal(t, "'\tTab", records[1][19])
+	assert.Equal(t, "Berlin", records[1][20])
+	assert.Equal(t, "'\rCup", records[1][32])
+
+	buf.Reset()
+	_, err = order.Export(context.Background(), service, siteID, &buf, order.ExportOptions{Format: order.FormatJSONL})
+	assert.Nil(t, err)
+
+	var row order.ExportRow
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &row))
+	assert.Equal(t, "@SUM(A1)", row.CustomerEmail)
+}
+
+func TestExportJSONL(t *testing.T) {
+	service := &fakeOrderList{orders: exportOrders()}
+
+	var buf bytes.Buffer
+	count, err := order.Export(context.Background(), service, siteID, &buf, order.ExportOptions{Format: order.FormatJSONL})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 4)
+
+	var row order.ExportRow
+	assert.Nil(t, json.Unmarshal([]byte(lines[1]), &row))
+	assert.Equal(t, "ab1-2cd", row.OrderID)
+	assert.Equal(t, "", row.ItemSKU)
+}
+
+func TestExportUnknownFormat(t *testing.T) {
+	_, err := order.Export(context.Background(), &fakeOrderList{}, siteID, &bytes.Buffer{}, order.ExportOptions{Format: "xml"})
+
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,133 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/nasrul21/go-webflow/order"
+	"github.com/stretchr/testify/mock"
+)
+
+// OrderMock is a testify mock of order.Order.
+// Configuration methods such as With... return the mock itself and are
+// not recorded.
+type OrderMock struct {
+	mock.Mock
+	// Machine is returned by StateMachine, order.NewStateMachine() when nil.
+	Machine *order.StateMachine
+}
+
+var _ order.Order = (*OrderMock)(nil)
+
+func (m *OrderMock) GetList(siteID string, params model.OrderListParams) ([]model.Order, *common.Error) {
+	args := m.Called(siteID, params)
+	res, _ := args.Get(0).([]model.Order)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *OrderMock) GetListWithContext(ctx context.Context, siteID string, params model.OrderListParams) ([]model.Order, *common.Error) {
+	args := m.Called(ctx, siteID, params)
+	res, _ := args.Get(0).([]model.Order)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *OrderMock) Get(siteID string, orderID string) (*model.Order, *common.Error) {
+	args := m.Called(siteID, orderID)
+	res, _ := args.Get(0).(*model.Order)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *OrderMock) GetWithContext(ctx context.Context, siteID string, orderID string) (*model.Order, *common.Error) {
+	args := m.Called(ctx, siteID, orderID)
+	res, _ := args.Get(0).(*model.Order)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *OrderMock) Update(siteID string, orderID string, req model.UpdateOrderRequest) (*model.Order, *common.Error) {
+	args := m.Called(siteID, orderID, req)
+	res, _ := args.Get(0).(*model.Order)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *OrderMock) UpdateWithContext(ctx context.Context, siteID string, orderID string, req model.UpdateOrderRequest) (*model.Order, *common.Error) {
+	args := m.Called(ctx, siteID, orderID, req)
+	res, _ := args.Get(0).(*model.Order)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *OrderMock) Fulfill(siteID string, orderID string, req model.FulfillOrderRequest) (*model.Order, *common.Error) {
+	args := m.Called(siteID, orderID, req)
+	res, _ := args.Get(0).(*model.Order)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *OrderMock) FulfillWithContext(ctx context.Context, siteID string, orderID string, req model.FulfillOrderRequest) (*model.Order, *common.Error) {
+	args := m.Called(ctx, siteID, orderID, req)
+	res, _ := args.Get(0).(*model.Order)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *OrderMock) Unfulfill(siteID string, orderID string) (*model.Order, *common.Error) {
+	args := m.Called(siteID, orderID)
+	res, _ := args.Get(0).(*model.Order)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *OrderMock) UnfulfillWithContext(ctx context.Context, siteID string, orderID string) (*model.Order, *common.Error) {
+	args := m.Called(ctx, siteID, orderID)
+	res, _ := args.Get(0).(*model.Order)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *OrderMock) Refund(siteID string, orderID string) (*model.Order, *common.Error) {
+	args := m.Called(siteID, orderID)
+	res, _ := args.Get(0).(*model.Order)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *OrderMock) RefundWithContext(ctx context.Context, siteID string, orderID string) (*model.Order, *common.Error) {
+	args := m.Called(ctx, siteID, orderID)
+	res, _ := args.Get(0).(*model.Order)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *OrderMock) StateMachine() *order.StateMachine {
+	if m.Machine != nil {
+		return m.Machine
+	}
+
+	return order.NewStateMachine()
+}
+
+func (m *OrderMock) WithoutTransitionValidation() order.Order {
+	return m
+}
+
+func (m *OrderMock) WithCurrencySource(source common.CurrencySource) order.Order {
+	return m
+}
@@ -0,0 +1,36 @@
+package mock_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/nasrul21/go-webflow/order"
+	"github.com/nasrul21/go-webflow/order/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+const siteID = "580e63e98c9a982ac9b8b741"
+
+func TestOrderMock(t *testing.T) {
+	orderMock := new(mock.OrderMock)
+	orderMock.On("GetListWithContext", context.Background(), siteID, model.OrderListParams{
+		PaginationParams: model.PaginationParams{Limit: 100},
+	}).Return([]model.Order{{OrderID: "dd6-5ca", Status: model.OrderStatusFulfilled}}, nil).Once()
+	orderMock.On("Refund", siteID, "dd6-5ca").Return(nil, &common.Error{Code: http.StatusConflict}).Once()
+
+	var buf bytes.Buffer
+	count, err := order.Export(context.Background(), orderMock, siteID, &buf, order.ExportOptions{Format: order.FormatJSONL})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	res, err := orderMock.Refund(siteID, "dd6-5ca")
+	assert.Nil(t, res)
+	assert.Equal(t, http.StatusConflict, err.Code)
+
+	assert.Equal(t, order.NewStateMachine(), orderMock.WithoutTransitionValidation().StateMachine())
+	orderMock.AssertExpectations(t)
+}
@@ -0,0 +1,211 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/nasrul21/go-webflow/client"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+)
+
+type Order interface {
+	GetList(siteID string, params model.OrderListParams) ([]model.Order, *common.Error)
+	GetListWithContext(ctx context.Context, siteID string, params model.OrderListParams) ([]model.Order, *common.Error)
+	Get(siteID string, orderID string) (*model.Order, *common.Error)
+	GetWithContext(ctx context.Context, siteID string, orderID string) (*model.Order, *common.Error)
+	Update(siteID string, orderID string, req model.UpdateOrderRequest) (*model.Order, *common.Error)
+	UpdateWithContext(ctx context.Context, siteID string, orderID string, req model.UpdateOrderRequest) (*model.Order, *common.Error)
+	Fulfill(siteID string, orderID string, req model.FulfillOrderRequest) (*model.Order, *common.Error)
+	FulfillWithContext(ctx context.Context, siteID string, orderID string, req model.FulfillOrderRequest) (*model.Order, *common.Error)
+	Unfulfill(siteID string, orderID string) (*model.Order, *common.Error)
+	UnfulfillWithContext(ctx context.Context, siteID string, orderID string) (*model.Order, *common.Error)
+	Refund(siteID string, orderID string) (*model.Order, *common.Error)
+	RefundWithContext(ctx context.Context, siteID string, orderID string) (*model.Order, *common.Error)
+	StateMachine() *StateMachine
+	WithoutTransitionValidation() Order
+	WithCurrencySource(source common.CurrencySource) Order
+}
+
+type OrderImpl struct {
+	Opt    *common.Option
+	Client client.Client
+	// Machine validates fulfill, unfulfill and refund against the current
+	// order status, which costs an extra Get call per action. Set
+	// SkipValidation to call the API directly.
+	Machine        *StateMachine
+	SkipValidation bool
+	// Currency, when set, fills in the site default currency on amounts
+	// returned without a unit.
+	Currency common.CurrencySource
+}
+
+func New(opt *common.Option, client client.Client) Order {
+	return &OrderImpl{
+		Opt:     opt,
+		Client:  client,
+		Machine: NewStateMachine(),
+	}
+}
+
+func (o *OrderImpl) StateMachine() *StateMachine {
+	return o.Machine
+}
+
+// WithoutTransitionValidation returns a copy of the service that calls the
+// API directly, leaving o unchanged.
+func (o *OrderImpl) WithoutTransitionValidation() Order {
+	unvalidated := *o
+	unvalidated.SkipValidation = true
+	return &unvalidated
+}
+
+func (o *OrderImpl) WithCurrencySource(source common.CurrencySource) Order {
+	o.Currency = source
+	return o
+}
+
+func (o *OrderImpl) GetList(siteID string, params model.OrderListParams) ([]model.Order, *common.Error) {
+	return o.GetListWithContext(context.Background(), siteID, params)
+}
+
+func (o *OrderImpl) GetListWithContext(ctx context.Context, siteID string, params model.OrderListParams) ([]model.Order, *common.Error) {
+	response := []model.Order{}
+	var header http.Header
+
+	url := fmt.Sprintf("%s/sites/%s/orders", o.Opt.BaseURL, siteID)
+	if query := params.Query().Encode(); query != "" {
+		url += "?" + query
+	}
+
+	err := o.Client.Call(
+		ctx,
+		http.MethodGet,
+		url,
+		o.Opt.ApiKey,
+		header,
+		nil,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var amounts []*model.Money
+	for i := range response {
+		amounts = append(amounts, response[i].Amounts()...)
+	}
+	o.defaultCurrency(ctx, siteID, amounts)
+
+	return response, nil
+}
+
+func (o *OrderImpl) Get(siteID string, orderID string) (*model.Order, *common.Error) {
+	return o.GetWithContext(context.Background(), siteID, orderID)
+}
+
+func (o *OrderImpl) GetWithContext(ctx context.Context, siteID string, orderID string) (*model.Order, *common.Error) {
+	return o.call(ctx, siteID, http.MethodGet, o.orderURL(siteID, orderID, ""), nil)
+}
+
+func (o *OrderImpl) Update(siteID string, orderID string, req model.UpdateOrderRequest) (*model.Order, *common.Error) {
+	return o.UpdateWithContext(context.Background(), siteID, orderID, req)
+}
+
+func (o *OrderImpl) UpdateWithContext(ctx context.Context, siteID string, orderID string, req model.UpdateOrderRequest) (*model.Order, *common.Error) {
+	return o.call(ctx, siteID, http.MethodPatch, o.orderURL(siteID, orderID, ""), req)
+}
+
+func (o *OrderImpl) Fulfill(siteID string, orderID string, req model.FulfillOrderRequest) (*model.Order, *common.Error) {
+	return o.FulfillWithContext(context.Background(), siteID, orderID, req)
+}
+
+func (o *OrderImpl) FulfillWithContext(ctx context.Context, siteID string, orderID string, req model.FulfillOrderRequest) (*model.Order, *common.Error) {
+	if err := o.validate(ctx, siteID, orderID, ActionFulfill); err != nil {
+		return nil, err
+	}
+
+	return o.call(ctx, siteID, http.MethodPost, o.orderURL(siteID, orderID, "/fulfill"), req)
+}
+
+func (o *OrderImpl) Unfulfill(siteID string, orderID string) (*model.Order, *common.Error) {
+	return o.UnfulfillWithContext(context.Background(), siteID, orderID)
+}
+
+func (o *OrderImpl) UnfulfillWithContext(ctx context.Context, siteID string, orderID string) (*model.Order, *common.Error) {
+	if err := o.validate(ctx, siteID, orderID, ActionUnfulfill); err != nil {
+		return nil, err
+	}
+
+	return o.call(ctx, siteID, http.MethodPost, o.orderURL(siteID, orderID, "/unfulfill"), nil)
+}
+
+func (o *OrderImpl) Refund(siteID string, orderID string) (*model.Order, *common.Error) {
+	return o.RefundWithContext(context.Background(), siteID, orderID)
+}
+
+func (o *OrderImpl) RefundWithContext(ctx context.Context, siteID string, orderID string) (*model.Order, *common.Error) {
+	if err := o.validate(ctx, siteID, orderID, ActionRefund); err != nil {
+		return nil, err
+	}
+
+	return o.call(ctx, siteID, http.MethodPost, o.orderURL(siteID, orderID, "/refund"), nil)
+}
+
+func (o *OrderImpl) validate(ctx context.Context, siteID string, orderID string, action string) *common.Error {
+	if o.SkipValidation || o.Machine == nil {
+		return nil
+	}
+
+	current, err := o.GetWithContext(ctx, siteID, orderID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := o.Machine.Next(current.Status, action); err != nil {
+		invalid := err.(*TransitionError)
+		invalid.OrderID = orderID
+		return transitionErr(invalid)
+	}
+
+	return nil
+}
+
+func (o *OrderImpl) orderURL(siteID string, orderID string, action string) string {
+	return fmt.Sprintf("%s/sites/%s/order/%s%s", o.Opt.BaseURL, siteID, orderID, action)
+}
+
+func (o *OrderImpl) call(ctx context.Context, siteID string, method string, url string, body interface{}) (*model.Order, *common.Error) {
+	var response model.Order
+	var header http.Header
+
+	err := o.Client.Call(
+		ctx,
+		method,
+		url,
+		o.Opt.ApiKey,
+		header,
+		body,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	o.defaultCurrency(ctx, siteID, response.Amounts())
+
+	return &response, nil
+}
+
+// defaultCurrency is best effort: when the currency cannot be resolved the
+// amounts are left as the API returned them.
+func (o *OrderImpl) defaultCurrency(ctx context.Context, siteID string, amounts []*model.Money) {
+	if o.Currency == nil || !model.MissingCurrency(amounts...) {
+		return
+	}
+
+	if unit, err := o.Currency.DefaultCurrency(ctx, siteID); err == nil {
+		model.DefaultCurrency(unit, amounts...)
+	}
+}
@@ -0,0 +1,345 @@
+package order_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nasrul21/go-webflow"
+	"github.com/nasrul21/go-webflow/client/mock"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/nasrul21/go-webflow/order"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	siteID  = "580e63e98c9a982ac9b8b741"
+	orderID = "dd6-5ca"
+)
+
+const orderJSON = `{
+	"orderId": "dd6-5ca",
+	"status": "unfulfilled",
+	"comment": "",
+	"orderComment": "Please gift wrap",
+	"acceptedOn": "2018-12-03T22:06:15.761Z",
+	"fulfilledOn": null,
+	"refundedOn": null,
+	"disputedOn": null,
+	"disputeUpdatedOn": null,
+	"disputeLastStatus": null,
+	"customerPaid": {"unit": "USD", "value": 6099, "string": "$60.99"},
+	"netAmount": {"unit": "USD", "value": 5892, "string": "$58.92"},
+	"shippingAddress": {
+		"type": "shipping",
+		"addressee": "Arthur Dent",
+		"line1": "20 Avenue Rd",
+		"line2": "Wiltshire",
+		"city": "London",
+		"state": "",
+		"country": "GB",
+		"postalCode": "NW8 6BU"
+	},
+	"billingAddress": null,
+	"shippingProvider": null,
+	"shippingTracking": null,
+	"customerInfo": {"fullName": "Arthur Dent", "email": "arthur.dent@example.com"},
+	"purchasedItems": [
+		{
+			"count": 1,
+			"rowTotal": {"unit": "USD", "value": 5500, "string": "$55.00"},
+			"productId": "5eb9fd05caef491eb9757183",
+			"productName": "White Cup",
+			"productSlug": "white-cup",
+			"variantId": "5eb9fd05caef491eb9757187",
+			"variantName": "White Cup Large",
+			"variantSlug": "white-cup-large",
+			"variantSKU": "cup-white-l",
+			"variantPrice": {"unit": "USD", "value": 5500, "string": "$55.00"}
+		}
+	],
+	"purchasedItemsCount": 1,
+	"totals": {
+		"subtotal": {"unit": "USD", "value": 5500, "string": "$55.00"},
+		"extras": [
+			{"type": "tax", "name": "State Taxes", "description": "NY Taxes (4%)", "price": {"unit": "USD", "value": 220, "string": "$2.20"}},
+			{"type": "shipping", "name": "Flat", "description": "", "price": {"unit": "USD", "value": 379, "string": "$3.79"}}
+		],
+		"total": {"unit": "USD", "value": 6099, "string": "$60.99"}
+	},
+	"paymentProcessor": "stripe",
+	"isShippingRequired": true
+}`
+
+func expectedOrder() *model.Order {
+	acceptedOn := time.Date(2018, 12, 3, 22, 6, 15, int(761*time.Millisecond), time.UTC)
+
+	return &model.Order{
+		OrderID:      orderID,
+		Status:       model.OrderStatusUnfulfilled,
+		OrderComment: "Please gift wrap",
+		AcceptedOn:   &acceptedOn,
+		CustomerPaid: model.Money{Unit: "USD", Value: 6099, Display: "$60.99"},
+		NetAmount:    model.Money{Unit: "USD", Value: 5892, Display: "$58.92"},
+		ShippingAddress: &model.OrderAddress{
+			Type:       "shipping",
+			Addressee:  "Arthur Dent",
+			Line1:      "20 Avenue Rd",
+			Line2:      "Wiltshire",
+			City:       "London",
+			Country:    "GB",
+			PostalCode: "NW8 6BU",
+		},
+		CustomerInfo: model.OrderCustomerInfo{FullName: "Arthur Dent", Email: "arthur.dent@example.com"},
+		PurchasedItems: []model.OrderPurchasedItem{
+			{
+				Count:        1,
+				RowTotal:     model.Money{Unit: "USD", Value: 5500, Display: "$55.00"},
+				ProductID:    "5eb9fd05caef491eb9757183",
+				ProductName:  "White Cup",
+				ProductSlug:  "white-cup",
+				VariantID:    "5eb9fd05caef491eb9757187",
+				VariantName:  "White Cup Large",
+				VariantSlug:  "white-cup-large",
+				VariantSKU:   "cup-white-l",
+				VariantPrice: model.Money{Unit: "USD", Value: 5500, Display: "$55.00"},
+			},
+		},
+		PurchasedItemsCount: 1,
+		Totals: model.OrderTotals{
+			Subtotal: model.Money{Unit: "USD", Value: 5500, Display: "$55.00"},
+			Extras: []model.OrderTotalExtra{
+				{Type: "tax", Name: "State Taxes", Description: "NY Taxes (4%)", Price: model.Money{Unit: "USD", Value: 220, Display: "$2.20"}},
+				{Type: "shipping", Name: "Flat", Price: model.Money{Unit: "USD", Value: 379, Display: "$3.79"}},
+			},
+			Total: model.Money{Unit: "USD", Value: 6099, Display: "$60.99"},
+		},
+		PaymentProcessor:   "stripe",
+		IsShippingRequired: true,
+	}
+}
+
+func TestGetList(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		_ = json.Unmarshal([]byte(fmt.Sprintf(`[%s]`, orderJSON)), &result)
+
+		return nil
+	}
+
+	testcases := []struct {
+		desc        string
+		params      model.OrderListParams
+		mockClosure func()
+		expectedRes []model.Order
+		expectedErr *common.Error
+	}{
+		{
+			desc: "should get list orders by status",
+			params: model.OrderListParams{
+				PaginationParams: model.PaginationParams{Offset: 100, Limit: 100},
+				Status:           model.OrderStatusUnfulfilled,
+			},
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/sites/%s/orders?limit=100&offset=100&status=unfulfilled", wf.Opt.BaseURL, siteID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&[]model.Order{},
+				).Return(nil).Once()
+			},
+			expectedRes: []model.Order{*expectedOrder()},
+			expectedErr: nil,
+		},
+		{
+			desc: "should return error",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/sites/%s/orders", wf.Opt.BaseURL, siteID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&[]model.Order{},
+				).Return(common.FromGoErr(fmt.Errorf("some error"))).Once()
+			},
+			expectedRes: nil,
+			expectedErr: common.FromGoErr(fmt.Errorf("some error")),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.mockClosure()
+
+			resp, err := wf.Order.GetList(siteID, tc.params)
+
+			assert.Equal(t, tc.expectedRes, resp)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestUpdateOrderRequestJSON(t *testing.T) {
+	body, err := json.Marshal(model.UpdateOrderRequest{ShippingProvider: "Royal Mail", ShippingTracking: "RM123456789GB"})
+
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"fields": {"shippingProvider": "Royal Mail", "shippingTracking": "RM123456789GB"}}`, string(body))
+}
+
+func TestOrderActions(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+	orders := wf.Order.WithoutTransitionValidation()
+	assert.False(t, wf.Order.(*order.OrderImpl).SkipValidation)
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		_ = json.Unmarshal([]byte(orderJSON), &result)
+
+		return nil
+	}
+
+	updateReq := model.UpdateOrderRequest{Comment: "Shipped with Royal Mail"}
+	fulfillReq := model.FulfillOrderRequest{SendOrderFulfilledEmail: true}
+
+	testcases := []struct {
+		desc        string
+		method      string
+		path        string
+		body        interface{}
+		call        func() (*model.Order, *common.Error)
+		returnErr   *common.Error
+		expectedRes *model.Order
+	}{
+		{
+			desc:        "should get order",
+			method:      http.MethodGet,
+			call:        func() (*model.Order, *common.Error) { return orders.Get(siteID, orderID) },
+			expectedRes: expectedOrder(),
+		},
+		{
+			desc:        "should update order",
+			method:      http.MethodPatch,
+			body:        updateReq,
+			call:        func() (*model.Order, *common.Error) { return orders.Update(siteID, orderID, updateReq) },
+			expectedRes: expectedOrder(),
+		},
+		{
+			desc:        "should fulfill order",
+			method:      http.MethodPost,
+			path:        "/fulfill",
+			body:        fulfillReq,
+			call:        func() (*model.Order, *common.Error) { return orders.Fulfill(siteID, orderID, fulfillReq) },
+			expectedRes: expectedOrder(),
+		},
+		{
+			desc:        "should unfulfill order",
+			method:      http.MethodPost,
+			path:        "/unfulfill",
+			call:        func() (*model.Order, *common.Error) { return orders.Unfulfill(siteID, orderID) },
+			expectedRes: expectedOrder(),
+		},
+		{
+			desc:        "should refund order",
+			method:      http.MethodPost,
+			path:        "/refund",
+			call:        func() (*model.Order, *common.Error) { return orders.Refund(siteID, orderID) },
+			expectedRes: expectedOrder(),
+		},
+		{
+			desc:        "should return error",
+			method:      http.MethodPost,
+			path:        "/refund",
+			call:        func() (*model.Order, *common.Error) { return orders.Refund(siteID, orderID) },
+			returnErr:   common.FromGoErr(fmt.Errorf("some error")),
+			expectedRes: nil,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var returnErr interface{}
+			if tc.returnErr != nil {
+				returnErr = tc.returnErr
+			}
+
+			httpClientMockObj.On(
+				"Call",
+				context.Background(),
+				tc.method,
+				fmt.Sprintf("%s/sites/%s/order/%s%s", wf.Opt.BaseURL, siteID, orderID, tc.path),
+				wf.Opt.ApiKey,
+				http.Header(nil),
+				tc.body,
+				&model.Order{},
+			).Return(returnErr).Once()
+
+			resp, err := tc.call()
+
+			assert.Equal(t, tc.expectedRes, resp)
+			assert.Equal(t, tc.returnErr, err)
+		})
+	}
+}
+
+type currencySource map[string]string
+
+func (c currencySource) DefaultCurrency(ctx context.Context, siteID string) (string, *common.Error) {
+	currency, ok := c[siteID]
+	if !ok {
+		return "", &common.Error{Code: http.StatusNotFound}
+	}
+
+	return currency, nil
+}
+
+func TestWithCurrencySource(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+	wf.Order.WithCurrencySource(currencySource{siteID: "GBP"})
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		resultString := `{
+			"orderId": "dd6-5ca",
+			"customerPaid": {"value": 6099},
+			"netAmount": {"unit": "USD", "value": 5892},
+			"purchasedItems": [{"count": 1, "rowTotal": {"value": 5500}, "variantPrice": {"value": 5500}}],
+			"totals": {"subtotal": {"value": 5500}, "total": {"value": 6099}}
+		}`
+
+		_ = json.Unmarshal([]byte(resultString), &result)
+
+		return nil
+	}
+	httpClientMockObj.On(
+		"Call",
+		context.Background(),
+		http.MethodGet,
+		fmt.Sprintf("%s/sites/%s/order/%s", wf.Opt.BaseURL, siteID, orderID),
+		wf.Opt.ApiKey,
+		http.Header(nil),
+		nil,
+		&model.Order{},
+	).Return(nil).Once()
+
+	resp, err := wf.Order.Get(siteID, orderID)
+
+	assert.Nil(t, err)
+	assert.Equal(t, model.NewMoney(6099, "GBP"), resp.CustomerPaid)
+	assert.Equal(t, model.NewMoney(5892, "USD"), resp.NetAmount)
+	assert.Equal(t, model.NewMoney(5500, "GBP"), resp.PurchasedItems[0].RowTotal)
+	assert.Equal(t, model.NewMoney(6099, "GBP"), resp.Totals.Total)
+	assert.Nil(t, resp.ApplicationFee)
+}
@@ -0,0 +1,136 @@
+package order
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+)
+
+const (
+	ActionFulfill   string = "fulfill"
+	ActionUnfulfill string = "unfulfill"
+	ActionRefund    string = "refund"
+)
+
+type Transition struct {
+	Action string
+	From   string
+	To     string
+}
+
+// StateMachine lists the order status transitions the API accepts.
+type StateMachine struct {
+	Transitions []Transition
+}
+
+var defaultTransitions = []Transition{
+	{Action: ActionFulfill, From: model.OrderStatusUnfulfilled, To: model.OrderStatusFulfilled},
+	{Action: ActionUnfulfill, From: model.OrderStatusFulfilled, To: model.OrderStatusUnfulfilled},
+	{Action: ActionRefund, From: model.OrderStatusUnfulfilled, To: model.OrderStatusRefunded},
+	{Action: ActionRefund, From: model.OrderStatusFulfilled, To: model.OrderStatusRefunded},
+}
+
+// NewStateMachine returns a machine with the transitions the API accepts.
+// Every call returns a new copy, so changing its transitions does not
+// affect other services.
+func NewStateMachine() *StateMachine {
+	return &StateMachine{
+		Transitions: append([]Transition(nil), defaultTransitions...),
+	}
+}
+
+// Allowed returns the transitions available to an order in status.
+func (s *StateMachine) Allowed(status string) []Transition {
+	var allowed []Transition
+	for _, t := range s.Transitions {
+		if t.From == status {
+			allowed = append(allowed, t)
+		}
+	}
+
+	return allowed
+}
+
+// AllowedActions returns the actions available to an order in status.
+func (s *StateMachine) AllowedActions(status string) []string {
+	var actions []string
+	for _, t := range s.Allowed(status) {
+		actions = append(actions, t.Action)
+	}
+
+	return actions
+}
+
+func (s *StateMachine) Can(status string, action string) bool {
+	_, ok := s.find(status, action)
+	return ok
+}
+
+// Next returns the status an order in status moves to after action, or a
+// *TransitionError when the action is not allowed.
+func (s *StateMachine) Next(status string, action string) (string, error) {
+	t, ok := s.find(status, action)
+	if !ok {
+		return "", &TransitionError{
+			Status:  status,
+			Action:  action,
+			Allowed: s.AllowedActions(status),
+		}
+	}
+
+	return t.To, nil
+}
+
+func (s *StateMachine) find(status string, action string) (Transition, bool) {
+	for _, t := range s.Transitions {
+		if t.From == status && t.Action == action {
+			return t, true
+		}
+	}
+
+	return Transition{}, false
+}
+
+type TransitionError struct {
+	OrderID string
+	Status  string
+	Action  string
+	Allowed []string
+}
+
+func (e *TransitionError) Error() string {
+	allowed := "none"
+	if len(e.Allowed) > 0 {
+		allowed = strings.Join(e.Allowed, ", ")
+	}
+
+	subject := "order"
+	if e.OrderID != "" {
+		subject = fmt.Sprintf("order %s", e.OrderID)
+	}
+
+	return fmt.Sprintf("%s: cannot %s in status %q, allowed actions: %s", subject, e.Action, e.Status, allowed)
+}
+
+func transitionErr(err *TransitionError) *common.Error {
+	return &common.Error{
+		Code:     http.StatusConflict,
+		Err:      common.InvalidTransitionErrCode,
+		Message:  err.Error(),
+		Problems: err,
+	}
+}
+
+// AsTransitionError returns the *TransitionError carried by err when the
+// call was rejected by the state machine before reaching the API.
+func AsTransitionError(err *common.Error) (*TransitionError, bool) {
+	if err == nil || err.Err != common.InvalidTransitionErrCode {
+		return nil, false
+	}
+
+	invalid, ok := err.Problems.(*TransitionError)
+	return invalid, ok
+}
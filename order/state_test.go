@@ -0,0 +1,125 @@
+package order_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nasrul21/go-webflow"
+	"github.com/nasrul21/go-webflow/client/mock"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/nasrul21/go-webflow/order"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateMachine(t *testing.T) {
+	machine := order.NewStateMachine()
+
+	assert.Equal(t, []string{order.ActionUnfulfill, order.ActionRefund}, machine.AllowedActions(model.OrderStatusFulfilled))
+	assert.Empty(t, machine.AllowedActions(model.OrderStatusRefunded))
+	assert.True(t, machine.Can(model.OrderStatusUnfulfilled, order.ActionFulfill))
+	assert.False(t, machine.Can(model.OrderStatusDisputed, order.ActionRefund))
+
+	next, err := machine.Next(model.OrderStatusUnfulfilled, order.ActionFulfill)
+	assert.Nil(t, err)
+	assert.Equal(t, model.OrderStatusFulfilled, next)
+
+	_, err = machine.Next(model.OrderStatusPending, order.ActionFulfill)
+	assert.Equal(t, &order.TransitionError{Status: model.OrderStatusPending, Action: order.ActionFulfill}, err)
+	assert.Equal(t, `order: cannot fulfill in status "pending", allowed actions: none`, err.Error())
+
+	machine.Transitions[0].To = model.OrderStatusRefunded
+	next, _ = order.NewStateMachine().Next(model.OrderStatusUnfulfilled, order.ActionFulfill)
+	assert.Equal(t, model.OrderStatusFulfilled, next)
+}
+
+func TestTransitionValidation(t *testing.T) {
+	testcases := []struct {
+		desc            string
+		status          string
+		call            func(o order.Order) (*model.Order, *common.Error)
+		path            string
+		expectedErrText string
+	}{
+		{
+			desc:   "should fulfill unfulfilled order",
+			status: model.OrderStatusUnfulfilled,
+			call: func(o order.Order) (*model.Order, *common.Error) {
+				return o.Fulfill(siteID, orderID, model.FulfillOrderRequest{})
+			},
+			path: "/fulfill",
+		},
+		{
+			desc:   "should reject refund of refunded order",
+			status: model.OrderStatusRefunded,
+			call: func(o order.Order) (*model.Order, *common.Error) {
+				return o.Refund(siteID, orderID)
+			},
+			expectedErrText: `order dd6-5ca: cannot refund in status "refunded", allowed actions: none`,
+		},
+		{
+			desc:   "should reject unfulfill of unfulfilled order",
+			status: model.OrderStatusUnfulfilled,
+			call: func(o order.Order) (*model.Order, *common.Error) {
+				return o.Unfulfill(siteID, orderID)
+			},
+			expectedErrText: `order dd6-5ca: cannot unfulfill in status "unfulfilled", allowed actions: fulfill, refund`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			httpClientMockObj := new(mock.ClientMock)
+			wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+			httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+				resultString := strings.Replace(orderJSON, `"status": "unfulfilled"`, fmt.Sprintf(`"status": %q`, tc.status), 1)
+				_ = json.Unmarshal([]byte(resultString), &result)
+				return nil
+			}
+			httpClientMockObj.On(
+				"Call",
+				context.Background(),
+				http.MethodGet,
+				fmt.Sprintf("%s/sites/%s/order/%s", wf.Opt.BaseURL, siteID, orderID),
+				wf.Opt.ApiKey,
+				http.Header(nil),
+				nil,
+				&model.Order{},
+			).Return(nil).Once()
+			if tc.path != "" {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodPost,
+					fmt.Sprintf("%s/sites/%s/order/%s%s", wf.Opt.BaseURL, siteID, orderID, tc.path),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					model.FulfillOrderRequest{},
+					&model.Order{},
+				).Return(nil).Once()
+			}
+
+			resp, err := tc.call(wf.Order)
+
+			httpClientMockObj.AssertExpectations(t)
+			if tc.expectedErrText == "" {
+				assert.Nil(t, err)
+				assert.NotNil(t, resp)
+				return
+			}
+
+			assert.Nil(t, resp)
+			assert.Equal(t, http.StatusConflict, err.Code)
+			assert.Equal(t, tc.expectedErrText, err.Message)
+
+			transitionErr, ok := order.AsTransitionError(err)
+			assert.True(t, ok)
+			assert.Equal(t, tc.status, transitionErr.Status)
+		})
+	}
+}
@@ -0,0 +1,614 @@
+package product
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+)
+
+// Catalog CSV columns. Every row is one SKU; product columns are read from
+// the first row of each product. Columns named OptionPrefix plus an option
+// name, e.g. "option:Size", hold the SKU property values.
+const (
+	ColumnProductSlug    = "product_slug"
+	ColumnProductName    = "product_name"
+	ColumnDescription    = "description"
+	ColumnShippable      = "shippable"
+	ColumnTaxCategory    = "tax_category"
+	ColumnProductType    = "product_type"
+	ColumnSKU            = "sku"
+	ColumnSKUName        = "sku_name"
+	ColumnSKUSlug        = "sku_slug"
+	ColumnPrice          = "price"
+	ColumnCompareAtPrice = "compare_at_price"
+	ColumnCurrency       = "currency"
+	ColumnWeight         = "weight"
+	ColumnWidth          = "width"
+	ColumnLength         = "length"
+	ColumnHeight         = "height"
+
+	OptionPrefix = "option:"
+)
+
+var catalogColumns = []string{
+	ColumnProductSlug, ColumnProductName, ColumnDescription, ColumnShippable,
+	ColumnTaxCategory, ColumnProductType, ColumnSKU, ColumnSKUName, ColumnSKUSlug,
+	ColumnPrice, ColumnCompareAtPrice, ColumnCurrency,
+	ColumnWeight, ColumnWidth, ColumnLength, ColumnHeight,
+}
+
+const (
+	ImportCreated string = "created"
+	ImportUpdated string = "updated"
+	ImportFailed  string = "failed"
+)
+
+type ImportOptions struct {
+	// Columns maps CSV headers to catalog columns, e.g. "Retail Price" to
+	// ColumnPrice. Headers not in the map are used as they are.
+	Columns map[string]string
+	// Currency is used for prices of rows without a currency column.
+	Currency string
+}
+
+// ImportResult is the outcome of one CSV row. Line is the line number in
+// the CSV, the header being line 1.
+type ImportResult struct {
+	Line        int
+	ProductSlug string
+	SKU         string
+	Status      string
+	ProductID   string
+	SKUID       string
+	Err         *common.Error
+}
+
+type catalogRow struct {
+	line    int
+	values  map[string]string
+	options map[string]string
+	product model.ProductFields
+	sku     model.SKUFields
+}
+
+func (r *catalogRow) result() ImportResult {
+	return ImportResult{
+		Line:        r.line,
+		ProductSlug: r.product.Slug,
+		SKU:         r.sku.SKU,
+	}
+}
+
+// ImportCatalog creates or updates the products and SKUs of a catalog CSV.
+// Products are matched by slug and SKUs by SKU code, or by slug for rows
+// without one. It returns one result per data row; the error is only set
+// when the CSV or the existing catalog cannot be read.
+func ImportCatalog(ctx context.Context, service Product, siteID string, r io.Reader, opts ImportOptions) ([]ImportResult, *common.Error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, common.FromGoErr(fmt.Errorf("product: read catalog header: %w", err))
+	}
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if mapped, ok := opts.Columns[name]; ok {
+			name = mapped
+		}
+		header[i] = name
+	}
+
+	var results []ImportResult
+	var optionNames []string
+	groups := map[string][]*catalogRow{}
+	var slugs []string
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return results, common.FromGoErr(fmt.Errorf("product: read catalog line %d: %w", line, err))
+		}
+
+		row := &catalogRow{line: line, values: map[string]string{}, options: map[string]string{}}
+		for i, value := range record {
+			if i >= len(header) {
+				break
+			}
+			value = strings.TrimSpace(value)
+			if strings.HasPrefix(header[i], OptionPrefix) {
+				name := strings.TrimPrefix(header[i], OptionPrefix)
+				if value != "" {
+					row.options[name] = value
+				}
+				if !containsString(optionNames, name) {
+					optionNames = append(optionNames, name)
+				}
+				continue
+			}
+			row.values[header[i]] = value
+		}
+
+		if err := row.parse(opts.Currency); err != nil {
+			result := row.result()
+			result.Status = ImportFailed
+			result.Err = common.FromGoErr(err)
+			results = append(results, result)
+			continue
+		}
+
+		if _, ok := groups[row.product.Slug]; !ok {
+			slugs = append(slugs, row.product.Slug)
+		}
+		groups[row.product.Slug] = append(groups[row.product.Slug], row)
+	}
+
+	existing, listErr := catalogBySlug(ctx, service, siteID)
+	if listErr != nil {
+		return results, listErr
+	}
+
+	for _, slug := range slugs {
+		results = append(results, importProduct(ctx, service, siteID, existing[slug], groups[slug], optionNames)...)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Line < results[j].Line
+	})
+
+	return results, nil
+}
+
+func (r *catalogRow) parse(currency string) error {
+	v := r.values
+
+	r.product = model.ProductFields{
+		Name:        v[ColumnProductName],
+		Slug:        v[ColumnProductSlug],
+		Description: v[ColumnDescription],
+		TaxCategory: v[ColumnTaxCategory],
+		ProductType: v[ColumnProductType],
+	}
+	if r.product.Slug == "" {
+		r.product.Slug = model.Slugify(r.product.Name)
+	}
+	if r.product.Slug == "" {
+		return fmt.Errorf("missing %s or %s", ColumnProductSlug, ColumnProductName)
+	}
+	if v[ColumnShippable] != "" {
+		shippable, err := strconv.ParseBool(v[ColumnShippable])
+		if err != nil {
+			return fmt.Errorf("invalid %s %q", ColumnShippable, v[ColumnShippable])
+		}
+		r.product.Shippable = &shippable
+	}
+
+	r.sku = model.SKUFields{
+		Name: v[ColumnSKUName],
+		Slug: v[ColumnSKUSlug],
+		SKU:  v[ColumnSKU],
+	}
+
+	if v[ColumnCurrency] != "" {
+		currency = v[ColumnCurrency]
+	}
+	if v[ColumnPrice] == "" {
+		return fmt.Errorf("missing %s", ColumnPrice)
+	}
+	price, err := model.ParseMoney(v[ColumnPrice], currency)
+	if err != nil {
+		return err
+	}
+	if price.Value < 0 {
+		return fmt.Errorf("negative %s %s", ColumnPrice, v[ColumnPrice])
+	}
+	r.sku.Price = &price
+
+	if v[ColumnCompareAtPrice] != "" {
+		compareAt, err := model.ParseMoney(v[ColumnCompareAtPrice], currency)
+		if err != nil {
+			return err
+		}
+		r.sku.CompareAtPrice = &compareAt
+	}
+
+	dimensions := []struct {
+		column string
+		value  **float64
+	}{
+		{ColumnWeight, &r.sku.Weight},
+		{ColumnWidth, &r.sku.Width},
+		{ColumnLength, &r.sku.Length},
+		{ColumnHeight, &r.sku.Height},
+	}
+	for _, d := range dimensions {
+		if v[d.column] == "" {
+			continue
+		}
+		f, err := strconv.ParseFloat(v[d.column], 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q", d.column, v[d.column])
+		}
+		*d.value = &f
+	}
+
+	return nil
+}
+
+func importProduct(ctx context.Context, service Product, siteID string, current *model.ProductWithSKUs, rows []*catalogRow, optionNames []string) []ImportResult {
+	results := make([]ImportResult, len(rows))
+	for i, row := range rows {
+		results[i] = row.result()
+	}
+	fail := func(indexes []int, err *common.Error) {
+		for _, i := range indexes {
+			results[i].Status = ImportFailed
+			results[i].Err = err
+		}
+	}
+	all := make([]int, len(rows))
+	for i := range rows {
+		all[i] = i
+	}
+
+	fields := rows[0].product
+	var existingProps []model.SKUProperty
+	var existingSKUs []model.SKU
+	if current != nil {
+		existingProps = current.Product.SKUProperties
+		existingSKUs = current.SKUs
+		if fields.Name == "" {
+			fields.Name = current.Product.Name
+		}
+	}
+	if fields.Name == "" {
+		fail(all, common.FromGoErr(fmt.Errorf("missing %s", ColumnProductName)))
+		return results
+	}
+
+	fields.SKUProperties = mergeProperties(existingProps, rows, optionNames)
+	for _, row := range rows {
+		row.sku.SKUValues = skuValues(fields.SKUProperties, row.options)
+		if row.sku.Name == "" {
+			row.sku.Name = variantName(fields.Name, row.options, optionNames)
+		}
+	}
+
+	var productID string
+	var pending []int
+
+	if current == nil {
+		created, err := service.CreateWithContext(ctx, siteID, model.CreateProductRequest{Product: fields, SKU: rows[0].sku})
+		if err != nil {
+			fail(all, err)
+			return results
+		}
+
+		productID = created.Product.ID
+		results[0].Status = ImportCreated
+		results[0].SKUID = created.SKU.ID
+		for i := 1; i < len(rows); i++ {
+			pending = append(pending, i)
+		}
+	} else {
+		productID = current.Product.ID
+		if _, err := service.UpdateWithContext(ctx, siteID, productID, model.UpdateProductRequest{Product: fields}); err != nil {
+			fail(all, err)
+			return results
+		}
+
+		pending = all
+	}
+
+	var creates []int
+	for _, i := range pending {
+		results[i].ProductID = productID
+
+		sku := findSKU(existingSKUs, rows[i].sku)
+		if sku == nil {
+			creates = append(creates, i)
+			continue
+		}
+
+		updated, err := service.UpdateSKUWithContext(ctx, siteID, productID, sku.ID, model.UpdateSKURequest{SKU: rows[i].sku})
+		if err != nil {
+			fail([]int{i}, err)
+			continue
+		}
+		results[i].Status = ImportUpdated
+		results[i].SKUID = updated.ID
+	}
+	results[0].ProductID = productID
+
+	if len(creates) > 0 {
+		skus := make([]model.SKUFields, len(creates))
+		for j, i := range creates {
+			skus[j] = rows[i].sku
+		}
+
+		created, err := service.CreateSKUsWithContext(ctx, siteID, productID, model.CreateSKUsRequest{SKUs: skus})
+		if err != nil {
+			fail(creates, err)
+			return results
+		}
+		for j, i := range creates {
+			results[i].Status = ImportCreated
+			if j < len(created) {
+				results[i].SKUID = created[j].ID
+			}
+		}
+	}
+
+	return results
+}
+
+// mergeProperties adds the option values of rows to the existing SKU
+// properties, keeping the IDs of properties and values that already exist.
+func mergeProperties(existing []model.SKUProperty, rows []*catalogRow, optionNames []string) []model.SKUProperty {
+	properties := make([]model.SKUProperty, len(existing))
+	for i, property := range existing {
+		property.Enum = append([]model.SKUPropertyEnum(nil), property.Enum...)
+		properties[i] = property
+	}
+
+	for _, name := range optionNames {
+		index := -1
+		for i, property := range properties {
+			if strings.EqualFold(property.Name, name) {
+				index = i
+				break
+			}
+		}
+
+		for _, row := range rows {
+			value, ok := row.options[name]
+			if !ok {
+				continue
+			}
+
+			if index < 0 {
+				properties = append(properties, model.SKUProperty{
+					ID:   propertyID(name),
+					Name: name,
+					Slug: model.Slugify(name),
+				})
+				index = len(properties) - 1
+			}
+
+			if findEnum(properties[index], value) == nil {
+				properties[index].Enum = append(properties[index].Enum, model.SKUPropertyEnum{
+					ID:   enumID(name, value),
+					Name: value,
+					Slug: model.Slugify(value),
+				})
+			}
+		}
+	}
+
+	return properties
+}
+
+func skuValues(properties []model.SKUProperty, options map[string]string) map[string]string {
+	if len(options) == 0 {
+		return nil
+	}
+
+	values := map[string]string{}
+	for _, property := range properties {
+		for name, value := range options {
+			if !strings.EqualFold(property.Name, name) {
+				continue
+			}
+			if enum := findEnum(property, value); enum != nil {
+				values[property.ID] = enum.ID
+			}
+		}
+	}
+
+	return values
+}
+
+func findEnum(property model.SKUProperty, value string) *model.SKUPropertyEnum {
+	for i, enum := range property.Enum {
+		if strings.EqualFold(enum.Name, value) {
+			return &property.Enum[i]
+		}
+	}
+
+	return nil
+}
+
+// findSKU returns the existing SKU a row refers to, by SKU code, by slug when
+// the row has no code, and otherwise by option values, so rows without an
+// identifier update the variant they describe instead of duplicating it.
+// A row with a SKU code never matches a SKU holding another code.
+func findSKU(skus []model.SKU, fields model.SKUFields) *model.SKU {
+	for i, sku := range skus {
+		if fields.SKU != "" && sku.SKU == fields.SKU {
+			return &skus[i]
+		}
+		if fields.SKU == "" && fields.Slug != "" && sku.Slug == fields.Slug {
+			return &skus[i]
+		}
+	}
+
+	for i, sku := range skus {
+		if fields.SKU != "" && sku.SKU != "" {
+			continue
+		}
+		if sameValues(sku.SKUValues, fields.SKUValues) {
+			return &skus[i]
+		}
+	}
+
+	return nil
+}
+
+func sameValues(a map[string]string, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+func variantName(productName string, options map[string]string, optionNames []string) string {
+	parts := []string{productName}
+	for _, name := range optionNames {
+		if value, ok := options[name]; ok {
+			parts = append(parts, value)
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func catalogBySlug(ctx context.Context, service Product, siteID string) (map[string]*model.ProductWithSKUs, *common.Error) {
+	products := map[string]*model.ProductWithSKUs{}
+	err := forEachProduct(ctx, service, siteID, func(item model.ProductWithSKUs) {
+		products[item.Product.Slug] = &item
+	})
+
+	return products, err
+}
+
+func forEachProduct(ctx context.Context, service Product, siteID string, fn func(item model.ProductWithSKUs)) *common.Error {
+	params := model.PaginationParams{Limit: 100}
+	for {
+		list, err := service.GetListWithContext(ctx, siteID, params)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range list.Items {
+			fn(item)
+		}
+
+		params.Offset += len(list.Items)
+		if len(list.Items) == 0 || params.Offset >= list.Total {
+			return nil
+		}
+	}
+}
+
+// ExportCatalog writes every product and SKU of a site as a catalog CSV that
+// ImportCatalog reads back, and returns the number of SKU rows written.
+func ExportCatalog(ctx context.Context, service Product, siteID string, w io.Writer) (int, *common.Error) {
+	var items []model.ProductWithSKUs
+	var optionNames []string
+
+	err := forEachProduct(ctx, service, siteID, func(item model.ProductWithSKUs) {
+		items = append(items, item)
+		for _, property := range item.Product.SKUProperties {
+			if !containsString(optionNames, property.Name) {
+				optionNames = append(optionNames, property.Name)
+			}
+		}
+	})
+	if err != nil {
+		return 0, err
+	}
+	sort.Strings(optionNames)
+
+	writer := csv.NewWriter(w)
+	header := append([]string(nil), catalogColumns...)
+	for _, name := range optionNames {
+		header = append(header, OptionPrefix+name)
+	}
+	if err := writer.Write(header); err != nil {
+		return 0, common.FromGoErr(err)
+	}
+
+	count := 0
+	for _, item := range items {
+		for _, sku := range item.SKUs {
+			record := catalogRecord(item.Product, sku)
+			for _, name := range optionNames {
+				record = append(record, optionValue(item.Product.SKUProperties, sku.SKUValues, name))
+			}
+			if err := writer.Write(record); err != nil {
+				return count, common.FromGoErr(err)
+			}
+			count++
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return count, common.FromGoErr(err)
+	}
+
+	return count, nil
+}
+
+func catalogRecord(product model.Product, sku model.SKU) []string {
+	compareAt := ""
+	if sku.CompareAtPrice != nil {
+		compareAt = sku.CompareAtPrice.Amount()
+	}
+
+	return []string{
+		product.Slug,
+		product.Name,
+		product.Description,
+		strconv.FormatBool(product.Shippable),
+		product.TaxCategory,
+		product.ProductType,
+		sku.SKU,
+		sku.Name,
+		sku.Slug,
+		sku.Price.Amount(),
+		compareAt,
+		sku.Price.Unit,
+		formatFloat(sku.Weight),
+		formatFloat(sku.Width),
+		formatFloat(sku.Length),
+		formatFloat(sku.Height),
+	}
+}
+
+func optionValue(properties []model.SKUProperty, values map[string]string, name string) string {
+	for _, property := range properties {
+		if property.Name != name {
+			continue
+		}
+		for _, enum := range property.Enum {
+			if values[property.ID] == enum.ID {
+				return enum.Name
+			}
+		}
+	}
+
+	return ""
+}
+
+func formatFloat(f *float64) string {
+	if f == nil {
+		return ""
+	}
+
+	return strconv.FormatFloat(*f, 'f', -1, 64)
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
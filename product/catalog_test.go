@@ -0,0 +1,203 @@
+package product_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/nasrul21/go-webflow/product"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeCatalog keeps products in memory and hands out sequential IDs.
+type fakeCatalog struct {
+	product.Product
+	items   []model.ProductWithSKUs
+	nextID  int
+	updated []string
+}
+
+func (f *fakeCatalog) id() string {
+	f.nextID++
+	return fmt.Sprintf("id-%d", f.nextID)
+}
+
+func (f *fakeCatalog) sku(productID string, fields model.SKUFields) model.SKU {
+	return model.SKU{
+		ID:             f.id(),
+		Product:        productID,
+		Name:           fields.Name,
+		Slug:           fields.Slug,
+		SKU:            fields.SKU,
+		SKUValues:      fields.SKUValues,
+		Price:          *fields.Price,
+		CompareAtPrice: fields.CompareAtPrice,
+	}
+}
+
+func (f *fakeCatalog) find(productID string) *model.ProductWithSKUs {
+	for i := range f.items {
+		if f.items[i].Product.ID == productID {
+			return &f.items[i]
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeCatalog) GetListWithContext(ctx context.Context, siteID string, params model.PaginationParams) (*model.ProductList, *common.Error) {
+	end := params.Offset + params.Limit
+	if end > len(f.items) {
+		end = len(f.items)
+	}
+
+	return &model.ProductList{Items: f.items[params.Offset:end], Offset: params.Offset, Limit: params.Limit, Total: len(f.items)}, nil
+}
+
+func (f *fakeCatalog) CreateWithContext(ctx context.Context, siteID string, req model.CreateProductRequest) (*model.ProductWithDefaultSKU, *common.Error) {
+	p := model.Product{ID: f.id(), Name: req.Product.Name, Slug: req.Product.Slug, SKUProperties: req.Product.SKUProperties}
+	sku := f.sku(p.ID, req.SKU)
+	p.DefaultSKU = sku.ID
+	f.items = append(f.items, model.ProductWithSKUs{Product: p, SKUs: []model.SKU{sku}})
+
+	return &model.ProductWithDefaultSKU{Product: p, SKU: sku}, nil
+}
+
+func (f *fakeCatalog) UpdateWithContext(ctx context.Context, siteID string, productID string, req model.UpdateProductRequest) (*model.Product, *common.Error) {
+	item := f.find(productID)
+	item.Product.Name = req.Product.Name
+	item.Product.SKUProperties = req.Product.SKUProperties
+	f.updated = append(f.updated, productID)
+
+	return &item.Product, nil
+}
+
+func (f *fakeCatalog) CreateSKUsWithContext(ctx context.Context, siteID string, productID string, req model.CreateSKUsRequest) ([]model.SKU, *common.Error) {
+	item := f.find(productID)
+	var skus []model.SKU
+	for _, fields := range req.SKUs {
+		sku := f.sku(productID, fields)
+		item.SKUs = append(item.SKUs, sku)
+		skus = append(skus, sku)
+	}
+
+	return skus, nil
+}
+
+func (f *fakeCatalog) UpdateSKUWithContext(ctx context.Context, siteID string, productID string, skuID string, req model.UpdateSKURequest) (*model.SKU, *common.Error) {
+	item := f.find(productID)
+	for i, sku := range item.SKUs {
+		if sku.ID == skuID {
+			item.SKUs[i] = f.sku(productID, req.SKU)
+			item.SKUs[i].ID = skuID
+			return &item.SKUs[i], nil
+		}
+	}
+
+	return nil, &common.Error{Code: 404, Err: "Not Found"}
+}
+
+const catalogCSV = `Handle,product_name,sku,Retail Price,compare_at_price,option:Size,option:Color
+t-shirt,T-Shirt,TS-S-RED,20.00,25.00,S,Red
+t-shirt,T-Shirt,TS-M-RED,20.00,,M,Red
+mug,Mug,MUG-1,12.5,,,
+,,BROKEN,10.00,,,
+poster,Poster,POSTER-1,ten,,,
+`
+
+func TestImportCatalog(t *testing.T) {
+	service := &fakeCatalog{}
+
+	results, err := product.ImportCatalog(context.Background(), service, siteID, strings.NewReader(catalogCSV), product.ImportOptions{
+		Columns:  map[string]string{"Handle": product.ColumnProductSlug, "Retail Price": product.ColumnPrice},
+		Currency: "USD",
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, results, 5)
+
+	statuses := make([]string, len(results))
+	for i, result := range results {
+		statuses[i] = result.Status
+	}
+	assert.Equal(t, []string{product.ImportCreated, product.ImportCreated, product.ImportCreated, product.ImportFailed, product.ImportFailed}, statuses)
+	assert.Equal(t, 2, results[0].Line)
+	assert.NotEmpty(t, results[1].SKUID)
+	assert.Equal(t, results[0].ProductID, results[1].ProductID)
+	assert.Contains(t, results[3].Err.Message, "missing")
+	assert.Contains(t, results[4].Err.Message, "invalid amount")
+
+	assert.Len(t, service.items, 2)
+	shirt := service.items[0]
+	assert.Len(t, shirt.Product.SKUProperties, 2)
+	assert.Len(t, shirt.Product.SKUProperties[0].Enum, 2)
+	assert.Equal(t, "T-Shirt S Red", shirt.SKUs[0].Name)
+	assert.Equal(t, int64(2500), shirt.SKUs[0].CompareAtPrice.Value)
+	assert.Len(t, shirt.SKUs[1].SKUValues, 2)
+	assert.Equal(t, model.NewMoney(1250, "USD"), service.items[1].SKUs[0].Price)
+
+	update := "product_slug,sku,price,currency,option:Size\n" +
+		"t-shirt,TS-M-RED,22.00,USD,M\n" +
+		"t-shirt,TS-L-RED,24.00,USD,L\n"
+
+	results, err = product.ImportCatalog(context.Background(), service, siteID, strings.NewReader(update), product.ImportOptions{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, product.ImportUpdated, results[0].Status)
+	assert.Equal(t, product.ImportCreated, results[1].Status)
+	assert.Equal(t, []string{shirt.Product.ID}, service.updated)
+
+	shirt = service.items[0]
+	assert.Equal(t, "T-Shirt", shirt.Product.Name)
+	assert.Len(t, shirt.SKUs, 3)
+	assert.Equal(t, int64(2200), shirt.SKUs[1].Price.Value)
+	assert.Len(t, shirt.Product.SKUProperties[0].Enum, 3)
+
+	byOptions := "product_slug,price,currency,option:Size,option:Color\n" +
+		"t-shirt,21.00,USD,S,Red\n" +
+		"mug,13.00,USD,,\n"
+
+	results, err = product.ImportCatalog(context.Background(), service, siteID, strings.NewReader(byOptions), product.ImportOptions{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, product.ImportUpdated, results[0].Status)
+	assert.Equal(t, shirt.SKUs[0].ID, results[0].SKUID)
+	assert.Equal(t, product.ImportUpdated, results[1].Status)
+	assert.Len(t, service.items[0].SKUs, 3)
+	assert.Len(t, service.items[1].SKUs, 1)
+	assert.Equal(t, int64(1300), service.items[1].SKUs[0].Price.Value)
+}
+
+func TestExportCatalog(t *testing.T) {
+	service := &fakeCatalog{}
+	_, err := product.ImportCatalog(context.Background(), service, siteID, strings.NewReader(catalogCSV), product.ImportOptions{
+		Columns:  map[string]string{"Handle": product.ColumnProductSlug, "Retail Price": product.ColumnPrice},
+		Currency: "USD",
+	})
+	assert.Nil(t, err)
+
+	var buf bytes.Buffer
+	count, err := product.ExportCatalog(context.Background(), service, siteID, &buf)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+
+	records, _ := csv.NewReader(bytes.NewReader(buf.Bytes())).ReadAll()
+	assert.Len(t, records, 4)
+	assert.Equal(t, []string{"option:Color", "option:Size"}, records[0][len(records[0])-2:])
+	assert.Equal(t, []string{"t-shirt", "T-Shirt"}, records[1][:2])
+	assert.Equal(t, []string{"20.00", "25.00", "USD"}, records[1][9:12])
+	assert.Equal(t, []string{"Red", "S"}, records[1][len(records[1])-2:])
+
+	results, err := product.ImportCatalog(context.Background(), service, siteID, &buf, product.ImportOptions{})
+
+	assert.Nil(t, err)
+	for _, result := range results {
+		assert.Equal(t, product.ImportUpdated, result.Status)
+	}
+}
@@ -0,0 +1,119 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/nasrul21/go-webflow/product"
+	"github.com/stretchr/testify/mock"
+)
+
+// ProductMock is a testify mock of product.Product.
+// Configuration methods such as With... return the mock itself and are
+// not recorded.
+type ProductMock struct {
+	mock.Mock
+}
+
+var _ product.Product = (*ProductMock)(nil)
+
+func (m *ProductMock) Create(siteID string, req model.CreateProductRequest) (*model.ProductWithDefaultSKU, *common.Error) {
+	args := m.Called(siteID, req)
+	res, _ := args.Get(0).(*model.ProductWithDefaultSKU)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *ProductMock) CreateWithContext(ctx context.Context, siteID string, req model.CreateProductRequest) (*model.ProductWithDefaultSKU, *common.Error) {
+	args := m.Called(ctx, siteID, req)
+	res, _ := args.Get(0).(*model.ProductWithDefaultSKU)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *ProductMock) Update(siteID string, productID string, req model.UpdateProductRequest) (*model.Product, *common.Error) {
+	args := m.Called(siteID, productID, req)
+	res, _ := args.Get(0).(*model.Product)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *ProductMock) UpdateWithContext(ctx context.Context, siteID string, productID string, req model.UpdateProductRequest) (*model.Product, *common.Error) {
+	args := m.Called(ctx, siteID, productID, req)
+	res, _ := args.Get(0).(*model.Product)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *ProductMock) GetList(siteID string, params model.PaginationParams) (*model.ProductList, *common.Error) {
+	args := m.Called(siteID, params)
+	res, _ := args.Get(0).(*model.ProductList)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *ProductMock) GetListWithContext(ctx context.Context, siteID string, params model.PaginationParams) (*model.ProductList, *common.Error) {
+	args := m.Called(ctx, siteID, params)
+	res, _ := args.Get(0).(*model.ProductList)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *ProductMock) Get(siteID string, productID string) (*model.ProductWithSKUs, *common.Error) {
+	args := m.Called(siteID, productID)
+	res, _ := args.Get(0).(*model.ProductWithSKUs)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *ProductMock) GetWithContext(ctx context.Context, siteID string, productID string) (*model.ProductWithSKUs, *common.Error) {
+	args := m.Called(ctx, siteID, productID)
+	res, _ := args.Get(0).(*model.ProductWithSKUs)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *ProductMock) CreateSKUs(siteID string, productID string, req model.CreateSKUsRequest) ([]model.SKU, *common.Error) {
+	args := m.Called(siteID, productID, req)
+	res, _ := args.Get(0).([]model.SKU)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *ProductMock) CreateSKUsWithContext(ctx context.Context, siteID string, productID string, req model.CreateSKUsRequest) ([]model.SKU, *common.Error) {
+	args := m.Called(ctx, siteID, productID, req)
+	res, _ := args.Get(0).([]model.SKU)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *ProductMock) UpdateSKU(siteID string, productID string, skuID string, req model.UpdateSKURequest) (*model.SKU, *common.Error) {
+	args := m.Called(siteID, productID, skuID, req)
+	res, _ := args.Get(0).(*model.SKU)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *ProductMock) UpdateSKUWithContext(ctx context.Context, siteID string, productID string, skuID string, req model.UpdateSKURequest) (*model.SKU, *common.Error) {
+	args := m.Called(ctx, siteID, productID, skuID, req)
+	res, _ := args.Get(0).(*model.SKU)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *ProductMock) WithCurrencySource(source common.CurrencySource) product.Product {
+	return m
+}
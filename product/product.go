@@ -0,0 +1,240 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/nasrul21/go-webflow/client"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+)
+
+type Product interface {
+	Create(siteID string, req model.CreateProductRequest) (*model.ProductWithDefaultSKU, *common.Error)
+	CreateWithContext(ctx context.Context, siteID string, req model.CreateProductRequest) (*model.ProductWithDefaultSKU, *common.Error)
+	Update(siteID string, productID string, req model.UpdateProductRequest) (*model.Product, *common.Error)
+	UpdateWithContext(ctx context.Context, siteID string, productID string, req model.UpdateProductRequest) (*model.Product, *common.Error)
+	GetList(siteID string, params model.PaginationParams) (*model.ProductList, *common.Error)
+	GetListWithContext(ctx context.Context, siteID string, params model.PaginationParams) (*model.ProductList, *common.Error)
+	Get(siteID string, productID string) (*model.ProductWithSKUs, *common.Error)
+	GetWithContext(ctx context.Context, siteID string, productID string) (*model.ProductWithSKUs, *common.Error)
+	CreateSKUs(siteID string, productID string, req model.CreateSKUsRequest) ([]model.SKU, *common.Error)
+	CreateSKUsWithContext(ctx context.Context, siteID string, productID string, req model.CreateSKUsRequest) ([]model.SKU, *common.Error)
+	UpdateSKU(siteID string, productID string, skuID string, req model.UpdateSKURequest) (*model.SKU, *common.Error)
+	UpdateSKUWithContext(ctx context.Context, siteID string, productID string, skuID string, req model.UpdateSKURequest) (*model.SKU, *common.Error)
+	WithCurrencySource(source common.CurrencySource) Product
+}
+
+type ProductImpl struct {
+	Opt    *common.Option
+	Client client.Client
+	// Currency, when set, fills in the site default currency on SKU prices
+	// returned without a unit.
+	Currency common.CurrencySource
+}
+
+func New(opt *common.Option, client client.Client) Product {
+	return &ProductImpl{
+		Opt:    opt,
+		Client: client,
+	}
+}
+
+func (p *ProductImpl) WithCurrencySource(source common.CurrencySource) Product {
+	p.Currency = source
+	return p
+}
+
+func (p *ProductImpl) Create(siteID string, req model.CreateProductRequest) (*model.ProductWithDefaultSKU, *common.Error) {
+	return p.CreateWithContext(context.Background(), siteID, req)
+}
+
+func (p *ProductImpl) CreateWithContext(ctx context.Context, siteID string, req model.CreateProductRequest) (*model.ProductWithDefaultSKU, *common.Error) {
+	var response model.ProductWithDefaultSKU
+	var header http.Header
+
+	err := p.Client.Call(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("%s/sites/%s/products", p.Opt.BaseURL, siteID),
+		p.Opt.ApiKey,
+		header,
+		req,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	p.defaultCurrency(ctx, siteID, &response.SKU)
+
+	return &response, nil
+}
+
+func (p *ProductImpl) Update(siteID string, productID string, req model.UpdateProductRequest) (*model.Product, *common.Error) {
+	return p.UpdateWithContext(context.Background(), siteID, productID, req)
+}
+
+func (p *ProductImpl) UpdateWithContext(ctx context.Context, siteID string, productID string, req model.UpdateProductRequest) (*model.Product, *common.Error) {
+	var response model.Product
+	var header http.Header
+
+	err := p.Client.Call(
+		ctx,
+		http.MethodPatch,
+		fmt.Sprintf("%s/sites/%s/products/%s", p.Opt.BaseURL, siteID, productID),
+		p.Opt.ApiKey,
+		header,
+		req,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (p *ProductImpl) GetList(siteID string, params model.PaginationParams) (*model.ProductList, *common.Error) {
+	return p.GetListWithContext(context.Background(), siteID, params)
+}
+
+func (p *ProductImpl) GetListWithContext(ctx context.Context, siteID string, params model.PaginationParams) (*model.ProductList, *common.Error) {
+	var response model.ProductList
+	var header http.Header
+
+	url := fmt.Sprintf("%s/sites/%s/products", p.Opt.BaseURL, siteID)
+	if query := params.Query().Encode(); query != "" {
+		url += "?" + query
+	}
+
+	err := p.Client.Call(
+		ctx,
+		http.MethodGet,
+		url,
+		p.Opt.ApiKey,
+		header,
+		nil,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var skus []*model.SKU
+	for i := range response.Items {
+		for j := range response.Items[i].SKUs {
+			skus = append(skus, &response.Items[i].SKUs[j])
+		}
+	}
+	p.defaultCurrency(ctx, siteID, skus...)
+
+	return &response, nil
+}
+
+func (p *ProductImpl) Get(siteID string, productID string) (*model.ProductWithSKUs, *common.Error) {
+	return p.GetWithContext(context.Background(), siteID, productID)
+}
+
+func (p *ProductImpl) GetWithContext(ctx context.Context, siteID string, productID string) (*model.ProductWithSKUs, *common.Error) {
+	var response model.ProductWithSKUs
+	var header http.Header
+
+	err := p.Client.Call(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/sites/%s/products/%s", p.Opt.BaseURL, siteID, productID),
+		p.Opt.ApiKey,
+		header,
+		nil,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	skus := make([]*model.SKU, len(response.SKUs))
+	for i := range response.SKUs {
+		skus[i] = &response.SKUs[i]
+	}
+	p.defaultCurrency(ctx, siteID, skus...)
+
+	return &response, nil
+}
+
+func (p *ProductImpl) CreateSKUs(siteID string, productID string, req model.CreateSKUsRequest) ([]model.SKU, *common.Error) {
+	return p.CreateSKUsWithContext(context.Background(), siteID, productID, req)
+}
+
+func (p *ProductImpl) CreateSKUsWithContext(ctx context.Context, siteID string, productID string, req model.CreateSKUsRequest) ([]model.SKU, *common.Error) {
+	var response model.SKUList
+	var header http.Header
+
+	err := p.Client.Call(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("%s/sites/%s/products/%s/skus", p.Opt.BaseURL, siteID, productID),
+		p.Opt.ApiKey,
+		header,
+		req,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	skus := make([]*model.SKU, len(response.SKUs))
+	for i := range response.SKUs {
+		skus[i] = &response.SKUs[i]
+	}
+	p.defaultCurrency(ctx, siteID, skus...)
+
+	return response.SKUs, nil
+}
+
+func (p *ProductImpl) UpdateSKU(siteID string, productID string, skuID string, req model.UpdateSKURequest) (*model.SKU, *common.Error) {
+	return p.UpdateSKUWithContext(context.Background(), siteID, productID, skuID, req)
+}
+
+func (p *ProductImpl) UpdateSKUWithContext(ctx context.Context, siteID string, productID string, skuID string, req model.UpdateSKURequest) (*model.SKU, *common.Error) {
+	var response model.SKU
+	var header http.Header
+
+	err := p.Client.Call(
+		ctx,
+		http.MethodPatch,
+		fmt.Sprintf("%s/sites/%s/products/%s/skus/%s", p.Opt.BaseURL, siteID, productID, skuID),
+		p.Opt.ApiKey,
+		header,
+		req,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	p.defaultCurrency(ctx, siteID, &response)
+
+	return &response, nil
+}
+
+// defaultCurrency is best effort: when the currency cannot be resolved the
+// prices are left as the API returned them.
+func (p *ProductImpl) defaultCurrency(ctx context.Context, siteID string, skus ...*model.SKU) {
+	if p.Currency == nil {
+		return
+	}
+
+	var amounts []*model.Money
+	for _, sku := range skus {
+		amounts = append(amounts, sku.Amounts()...)
+	}
+	if !model.MissingCurrency(amounts...) {
+		return
+	}
+
+	if unit, err := p.Currency.DefaultCurrency(ctx, siteID); err == nil {
+		model.DefaultCurrency(unit, amounts...)
+	}
+}
@@ -0,0 +1,519 @@
+package product_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nasrul21/go-webflow"
+	"github.com/nasrul21/go-webflow/client/mock"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	siteID    = "580e63e98c9a982ac9b8b741"
+	productID = "5eb9fd05caef491eb9757183"
+)
+
+const productJSON = `{
+	"_id": "5eb9fd05caef491eb9757183",
+	"_cid": "5eb9fcfccaef49ef2a757174",
+	"_archived": false,
+	"_draft": false,
+	"name": "Cloak Of Invisibility",
+	"slug": "cloak-of-invisibility",
+	"description": "A cloak that renders the wearer invisible.",
+	"shippable": true,
+	"default-sku": "5eb9fd05caef491eb9757187",
+	"sku-properties": [
+		{
+			"id": "a37a7991f7ae3d3b8b4fa0e7d5b6ecba",
+			"name": "Color",
+			"enum": [{"id": "a4c5bfa8cba3b7a5f68e4ce6f0a20b9f", "name": "Black", "slug": "black"}]
+		}
+	],
+	"created-on": "2020-05-12T01:36:05.645Z"
+}`
+
+const skuJSON = `{
+	"_id": "5eb9fd05caef491eb9757187",
+	"_cid": "5eb9fcfccaef497de7757179",
+	"_archived": false,
+	"_draft": false,
+	"name": "Cloak Of Invisibility Black",
+	"slug": "cloak-of-invisibility-black",
+	"product": "5eb9fd05caef491eb9757183",
+	"sku-values": {"a37a7991f7ae3d3b8b4fa0e7d5b6ecba": "a4c5bfa8cba3b7a5f68e4ce6f0a20b9f"},
+	"price": {"unit": "USD", "value": 9900},
+	"compare-at-price": {"unit": "USD", "value": 12900},
+	"main-image": {"fileId": "5eb9fd05caef491eb9757190", "url": "https://uploads-ssl.webflow.com/cloak.png"}
+}`
+
+func expectedProduct() model.Product {
+	createdOn := time.Date(2020, 5, 12, 1, 36, 5, int(645*time.Millisecond), time.UTC)
+
+	return model.Product{
+		ID:           productID,
+		CollectionID: "5eb9fcfccaef49ef2a757174",
+		Name:         "Cloak Of Invisibility",
+		Slug:         "cloak-of-invisibility",
+		Description:  "A cloak that renders the wearer invisible.",
+		Shippable:    true,
+		DefaultSKU:   "5eb9fd05caef491eb9757187",
+		SKUProperties: []model.SKUProperty{
+			{
+				ID:   "a37a7991f7ae3d3b8b4fa0e7d5b6ecba",
+				Name: "Color",
+				Enum: []model.SKUPropertyEnum{{ID: "a4c5bfa8cba3b7a5f68e4ce6f0a20b9f", Name: "Black", Slug: "black"}},
+			},
+		},
+		CreatedOn: &createdOn,
+	}
+}
+
+func expectedSKU() model.SKU {
+	return model.SKU{
+		ID:             "5eb9fd05caef491eb9757187",
+		CollectionID:   "5eb9fcfccaef497de7757179",
+		Name:           "Cloak Of Invisibility Black",
+		Slug:           "cloak-of-invisibility-black",
+		Product:        productID,
+		SKUValues:      map[string]string{"a37a7991f7ae3d3b8b4fa0e7d5b6ecba": "a4c5bfa8cba3b7a5f68e4ce6f0a20b9f"},
+		Price:          model.Money{Unit: "USD", Value: 9900},
+		CompareAtPrice: &model.Money{Unit: "USD", Value: 12900},
+		MainImage:      &model.Image{FileID: "5eb9fd05caef491eb9757190", URL: "https://uploads-ssl.webflow.com/cloak.png"},
+	}
+}
+
+func TestCreateProductRequestJSON(t *testing.T) {
+	shippable := true
+	req := model.CreateProductRequest{
+		Product: model.ProductFields{Name: "Cloak Of Invisibility", Slug: "cloak-of-invisibility", Shippable: &shippable},
+		SKU:     model.SKUFields{Name: "Cloak Of Invisibility", Slug: "cloak-of-invisibility", Price: &model.Money{Unit: "USD", Value: 9900}},
+	}
+
+	body, err := json.Marshal(req)
+
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"product": {"fields": {"_archived": false, "_draft": false, "name": "Cloak Of Invisibility", "slug": "cloak-of-invisibility", "shippable": true}},
+		"sku": {"fields": {"_archived": false, "_draft": false, "name": "Cloak Of Invisibility", "slug": "cloak-of-invisibility", "price": {"unit": "USD", "value": 9900}}}
+	}`, string(body))
+}
+
+func TestUpdateRequestJSON(t *testing.T) {
+	body, err := json.Marshal(model.UpdateProductRequest{Product: model.ProductFields{Name: "Cloak Of Invisibility"}})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"fields": {"name": "Cloak Of Invisibility"}}`, string(body))
+
+	body, err = json.Marshal(model.UpdateSKURequest{SKU: model.SKUFields{SKU: "COI-BLACK"}})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"sku": {"fields": {"sku": "COI-BLACK"}}}`, string(body))
+
+	archived := true
+	body, err = json.Marshal(model.UpdateSKURequest{SKU: model.SKUFields{Archived: &archived}})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"sku": {"fields": {"_archived": true}}}`, string(body))
+}
+
+func TestCreate(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		resultString := fmt.Sprintf(`{"product": %s, "sku": %s}`, productJSON, skuJSON)
+
+		_ = json.Unmarshal([]byte(resultString), &result)
+
+		return nil
+	}
+
+	req := model.CreateProductRequest{
+		Product: model.ProductFields{Name: "Cloak Of Invisibility", Slug: "cloak-of-invisibility"},
+		SKU:     model.SKUFields{Price: &model.Money{Unit: "USD", Value: 9900}},
+	}
+
+	testcases := []struct {
+		desc        string
+		mockClosure func()
+		expectedRes *model.ProductWithDefaultSKU
+		expectedErr *common.Error
+	}{
+		{
+			desc: "should create product",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodPost,
+					fmt.Sprintf("%s/sites/%s/products", wf.Opt.BaseURL, siteID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					req,
+					&model.ProductWithDefaultSKU{},
+				).Return(nil).Once()
+			},
+			expectedRes: &model.ProductWithDefaultSKU{
+				Product: expectedProduct(),
+				SKU:     expectedSKU(),
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "should return error",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodPost,
+					fmt.Sprintf("%s/sites/%s/products", wf.Opt.BaseURL, siteID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					req,
+					&model.ProductWithDefaultSKU{},
+				).Return(common.FromGoErr(fmt.Errorf("some error"))).Once()
+			},
+			expectedRes: nil,
+			expectedErr: common.FromGoErr(fmt.Errorf("some error")),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.mockClosure()
+
+			resp, err := wf.Product.Create(siteID, req)
+
+			assert.Equal(t, tc.expectedRes, resp)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		_ = json.Unmarshal([]byte(productJSON), &result)
+
+		return nil
+	}
+
+	req := model.UpdateProductRequest{
+		Product: model.ProductFields{Description: "A cloak that renders the wearer invisible."},
+	}
+
+	testcases := []struct {
+		desc        string
+		mockClosure func()
+		expectedRes *model.Product
+		expectedErr *common.Error
+	}{
+		{
+			desc: "should update product",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodPatch,
+					fmt.Sprintf("%s/sites/%s/products/%s", wf.Opt.BaseURL, siteID, productID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					req,
+					&model.Product{},
+				).Return(nil).Once()
+			},
+			expectedRes: func() *model.Product {
+				product := expectedProduct()
+				return &product
+			}(),
+			expectedErr: nil,
+		},
+		{
+			desc: "should return error",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodPatch,
+					fmt.Sprintf("%s/sites/%s/products/%s", wf.Opt.BaseURL, siteID, productID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					req,
+					&model.Product{},
+				).Return(common.FromGoErr(fmt.Errorf("some error"))).Once()
+			},
+			expectedRes: nil,
+			expectedErr: common.FromGoErr(fmt.Errorf("some error")),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.mockClosure()
+
+			resp, err := wf.Product.Update(siteID, productID, req)
+
+			assert.Equal(t, tc.expectedRes, resp)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGetList(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		resultString := fmt.Sprintf(`{
+			"items": [{"product": %s, "skus": [%s]}],
+			"count": 1,
+			"limit": 10,
+			"offset": 20,
+			"total": 21
+		}`, productJSON, skuJSON)
+
+		_ = json.Unmarshal([]byte(resultString), &result)
+
+		return nil
+	}
+
+	testcases := []struct {
+		desc        string
+		params      model.PaginationParams
+		mockClosure func()
+		expectedRes *model.ProductList
+		expectedErr *common.Error
+	}{
+		{
+			desc:   "should get list products",
+			params: model.PaginationParams{Offset: 20, Limit: 10},
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/sites/%s/products?limit=10&offset=20", wf.Opt.BaseURL, siteID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&model.ProductList{},
+				).Return(nil).Once()
+			},
+			expectedRes: &model.ProductList{
+				Items:  []model.ProductWithSKUs{{Product: expectedProduct(), SKUs: []model.SKU{expectedSKU()}}},
+				Count:  1,
+				Limit:  10,
+				Offset: 20,
+				Total:  21,
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "should return error",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/sites/%s/products", wf.Opt.BaseURL, siteID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&model.ProductList{},
+				).Return(common.FromGoErr(fmt.Errorf("some error"))).Once()
+			},
+			expectedRes: nil,
+			expectedErr: common.FromGoErr(fmt.Errorf("some error")),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.mockClosure()
+
+			resp, err := wf.Product.GetList(siteID, tc.params)
+
+			assert.Equal(t, tc.expectedRes, resp)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		resultString := fmt.Sprintf(`{"product": %s, "skus": [%s]}`, productJSON, skuJSON)
+
+		_ = json.Unmarshal([]byte(resultString), &result)
+
+		return nil
+	}
+
+	testcases := []struct {
+		desc        string
+		mockClosure func()
+		expectedRes *model.ProductWithSKUs
+		expectedErr *common.Error
+	}{
+		{
+			desc: "should get product",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/sites/%s/products/%s", wf.Opt.BaseURL, siteID, productID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&model.ProductWithSKUs{},
+				).Return(nil).Once()
+			},
+			expectedRes: &model.ProductWithSKUs{
+				Product: expectedProduct(),
+				SKUs:    []model.SKU{expectedSKU()},
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "should return error",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/sites/%s/products/%s", wf.Opt.BaseURL, siteID, productID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&model.ProductWithSKUs{},
+				).Return(common.FromGoErr(fmt.Errorf("some error"))).Once()
+			},
+			expectedRes: nil,
+			expectedErr: common.FromGoErr(fmt.Errorf("some error")),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.mockClosure()
+
+			resp, err := wf.Product.Get(siteID, productID)
+
+			assert.Equal(t, tc.expectedRes, resp)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestCreateSKUs(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		resultString := fmt.Sprintf(`{"skus": [%s]}`, skuJSON)
+
+		_ = json.Unmarshal([]byte(resultString), &result)
+
+		return nil
+	}
+
+	req := model.CreateSKUsRequest{
+		SKUs: []model.SKUFields{{Name: "Cloak Of Invisibility Black", Price: &model.Money{Unit: "USD", Value: 9900}}},
+	}
+
+	testcases := []struct {
+		desc        string
+		mockClosure func()
+		expectedRes []model.SKU
+		expectedErr *common.Error
+	}{
+		{
+			desc: "should create skus",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodPost,
+					fmt.Sprintf("%s/sites/%s/products/%s/skus", wf.Opt.BaseURL, siteID, productID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					req,
+					&model.SKUList{},
+				).Return(nil).Once()
+			},
+			expectedRes: []model.SKU{expectedSKU()},
+			expectedErr: nil,
+		},
+		{
+			desc: "should return error",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodPost,
+					fmt.Sprintf("%s/sites/%s/products/%s/skus", wf.Opt.BaseURL, siteID, productID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					req,
+					&model.SKUList{},
+				).Return(common.FromGoErr(fmt.Errorf("some error"))).Once()
+			},
+			expectedRes: nil,
+			expectedErr: common.FromGoErr(fmt.Errorf("some error")),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.mockClosure()
+
+			resp, err := wf.Product.CreateSKUs(siteID, productID, req)
+
+			assert.Equal(t, tc.expectedRes, resp)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestUpdateSKU(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		_ = json.Unmarshal([]byte(skuJSON), &result)
+
+		return nil
+	}
+
+	skuID := "5eb9fd05caef491eb9757187"
+	req := model.UpdateSKURequest{
+		SKU: model.SKUFields{CompareAtPrice: &model.Money{Unit: "USD", Value: 12900}},
+	}
+
+	httpClientMockObj.On(
+		"Call",
+		context.Background(),
+		http.MethodPatch,
+		fmt.Sprintf("%s/sites/%s/products/%s/skus/%s", wf.Opt.BaseURL, siteID, productID, skuID),
+		wf.Opt.ApiKey,
+		http.Header(nil),
+		req,
+		&model.SKU{},
+	).Return(nil).Once()
+
+	resp, err := wf.Product.UpdateSKU(siteID, productID, skuID, req)
+
+	sku := expectedSKU()
+	assert.Nil(t, err)
+	assert.Equal(t, &sku, resp)
+}
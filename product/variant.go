@@ -0,0 +1,294 @@
+package product
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"time"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/inventory"
+	"github.com/nasrul21/go-webflow/model"
+)
+
+type VariantOption struct {
+	Name   string
+	Values []string
+}
+
+// Variant is one combination of option values, e.g. size M and colour red.
+type Variant struct {
+	// Values maps option name to option value.
+	Values map[string]string
+	Fields model.SKUFields
+	// Quantity is the inventory of the variant, nil when none was set. It is
+	// not part of the SKU fields; SubmitVariants writes it through the
+	// inventory endpoint once the SKU exists.
+	Quantity *int
+}
+
+type variantOverride struct {
+	match    map[string]string
+	price    *model.Money
+	quantity *int
+}
+
+// VariantMatrix builds the SKUs for every combination of a product's
+// options. Property and enum IDs are derived from the option names and
+// values, so building the same matrix twice yields the same IDs.
+type VariantMatrix struct {
+	ProductName string
+	Price       model.Money
+	Options     []VariantOption
+	overrides   []variantOverride
+}
+
+func NewVariantMatrix(productName string, price model.Money) *VariantMatrix {
+	return &VariantMatrix{
+		ProductName: productName,
+		Price:       price,
+	}
+}
+
+func (m *VariantMatrix) Option(name string, values ...string) *VariantMatrix {
+	m.Options = append(m.Options, VariantOption{Name: name, Values: values})
+	return m
+}
+
+// PriceFor sets the price of every variant whose values contain all of
+// match. Later overrides win over earlier ones.
+func (m *VariantMatrix) PriceFor(match map[string]string, price model.Money) *VariantMatrix {
+	m.overrides = append(m.overrides, variantOverride{match: match, price: &price})
+	return m
+}
+
+// QuantityFor sets the inventory of every variant whose values contain all
+// of match; a nil match applies to every variant.
+func (m *VariantMatrix) QuantityFor(match map[string]string, quantity int) *VariantMatrix {
+	m.overrides = append(m.overrides, variantOverride{match: match, quantity: &quantity})
+	return m
+}
+
+// Properties returns the SKU properties to set on the product before its
+// variants are created, e.g. through SubmitOptions.Properties.
+func (m *VariantMatrix) Properties() []model.SKUProperty {
+	properties := make([]model.SKUProperty, len(m.Options))
+	for i, option := range m.Options {
+		enum := make([]model.SKUPropertyEnum, len(option.Values))
+		for j, value := range option.Values {
+			enum[j] = model.SKUPropertyEnum{
+				ID:   enumID(option.Name, value),
+				Name: value,
+				Slug: model.Slugify(value),
+			}
+		}
+
+		properties[i] = model.SKUProperty{
+			ID:   propertyID(option.Name),
+			Name: option.Name,
+			Slug: model.Slugify(option.Name),
+			Enum: enum,
+		}
+	}
+
+	return properties
+}
+
+// Variants returns the cartesian product of the option values, with the
+// last option varying fastest.
+func (m *VariantMatrix) Variants() []Variant {
+	combinations := []map[string]string{{}}
+	for _, option := range m.Options {
+		next := make([]map[string]string, 0, len(combinations)*len(option.Values))
+		for _, combination := range combinations {
+			for _, value := range option.Values {
+				values := make(map[string]string, len(combination)+1)
+				for k, v := range combination {
+					values[k] = v
+				}
+				values[option.Name] = value
+				next = append(next, values)
+			}
+		}
+		combinations = next
+	}
+
+	variants := make([]Variant, len(combinations))
+	for i, values := range combinations {
+		variants[i] = m.variant(values)
+	}
+
+	return variants
+}
+
+func (m *VariantMatrix) variant(values map[string]string) Variant {
+	price := m.Price
+	var quantity *int
+	for _, override := range m.overrides {
+		if !matches(values, override.match) {
+			continue
+		}
+		if override.price != nil {
+			price = *override.price
+		}
+		if override.quantity != nil {
+			q := *override.quantity
+			quantity = &q
+		}
+	}
+
+	name := m.ProductName
+	skuValues := make(map[string]string, len(values))
+	for _, option := range m.Options {
+		name += " " + values[option.Name]
+		skuValues[propertyID(option.Name)] = enumID(option.Name, values[option.Name])
+	}
+
+	return Variant{
+		Values: values,
+		Fields: model.SKUFields{
+			Name:      name,
+			Slug:      model.Slugify(name),
+			SKUValues: skuValues,
+			Price:     &price,
+		},
+		Quantity: quantity,
+	}
+}
+
+func matches(values map[string]string, match map[string]string) bool {
+	for k, v := range match {
+		if values[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+func propertyID(name string) string {
+	hash := md5.Sum([]byte(name))
+	return hex.EncodeToString(hash[:])
+}
+
+func enumID(name string, value string) string {
+	hash := md5.Sum([]byte(name + ":" + value))
+	return hex.EncodeToString(hash[:])
+}
+
+type SubmitOptions struct {
+	// DefaultSKUID is the SKU Webflow created together with the product.
+	// When set, the first variant is written to it instead of being created.
+	DefaultSKUID string
+	// BatchSize is the number of SKUs per create call, 10 when zero.
+	BatchSize int
+	// Limiter, when set, paces every call made by SubmitVariants.
+	Limiter *common.Limiter
+	// MaxRetries is how often a rate limited call is retried, 3 when zero.
+	MaxRetries int
+	// RetryWait is the wait before retrying a rate limited call, multiplied
+	// by the attempt number. One minute when zero.
+	RetryWait time.Duration
+	// Properties, when set, replace the sku-properties of the product before
+	// any SKU is written, so the SKU values refer to existing properties.
+	Properties []model.SKUProperty
+	// Inventory, when set, is used to write the Quantity of every variant
+	// that has one after its SKU was created. Without it quantities are
+	// ignored.
+	Inventory inventory.Inventory
+}
+
+// SubmitVariants creates the SKUs of variants in batches and returns them in
+// variant order. On error the SKUs submitted so far are returned with it.
+func SubmitVariants(ctx context.Context, service Product, siteID string, productID string, variants []Variant, opts SubmitOptions) ([]model.SKU, *common.Error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 10
+	}
+	retry := common.RetryPolicy{
+		Limiter:    opts.Limiter,
+		MaxRetries: opts.MaxRetries,
+		Wait:       opts.RetryWait,
+	}
+
+	skus := make([]model.SKU, 0, len(variants))
+
+	if len(opts.Properties) > 0 {
+		err := retry.Do(ctx, func() *common.Error {
+			_, err := service.UpdateWithContext(ctx, siteID, productID, model.UpdateProductRequest{Product: model.ProductFields{SKUProperties: opts.Properties}})
+			return err
+		})
+		if err != nil {
+			return skus, err
+		}
+	}
+
+	setQuantities := func(skus []model.SKU, variants []Variant) *common.Error {
+		if opts.Inventory == nil {
+			return nil
+		}
+
+		for i, sku := range skus {
+			if i >= len(variants) || variants[i].Quantity == nil {
+				continue
+			}
+
+			req := model.SetInventoryQuantity(*variants[i].Quantity)
+			err := retry.Do(ctx, func() *common.Error {
+				_, err := opts.Inventory.UpdateWithContext(ctx, sku.CollectionID, sku.ID, req)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if opts.DefaultSKUID != "" && len(variants) > 0 {
+		var sku *model.SKU
+		err := retry.Do(ctx, func() *common.Error {
+			var err *common.Error
+			sku, err = service.UpdateSKUWithContext(ctx, siteID, productID, opts.DefaultSKUID, model.UpdateSKURequest{SKU: variants[0].Fields})
+			return err
+		})
+		if err != nil {
+			return skus, err
+		}
+
+		skus = append(skus, *sku)
+		if err := setQuantities(skus, variants[:1]); err != nil {
+			return skus, err
+		}
+		variants = variants[1:]
+	}
+
+	for start := 0; start < len(variants); start += opts.BatchSize {
+		end := start + opts.BatchSize
+		if end > len(variants) {
+			end = len(variants)
+		}
+
+		fields := make([]model.SKUFields, 0, end-start)
+		for _, variant := range variants[start:end] {
+			fields = append(fields, variant.Fields)
+		}
+
+		var created []model.SKU
+		err := retry.Do(ctx, func() *common.Error {
+			var err *common.Error
+			created, err = service.CreateSKUsWithContext(ctx, siteID, productID, model.CreateSKUsRequest{SKUs: fields})
+			return err
+		})
+		if err != nil {
+			return skus, err
+		}
+
+		skus = append(skus, created...)
+		if err := setQuantities(created, variants[start:end]); err != nil {
+			return skus, err
+		}
+	}
+
+	return skus, nil
+}
@@ -0,0 +1,184 @@
+package product_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/nasrul21/go-webflow/product"
+	"github.com/nasrul21/go-webflow/webflowtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMatrix() *product.VariantMatrix {
+	return product.NewVariantMatrix("T-Shirt", model.Money{Unit: "USD", Value: 2000}).
+		Option("Size", "S", "M", "XL").
+		Option("Color", "Red", "Navy Blue").
+		PriceFor(map[string]string{"Size": "XL"}, model.Money{Unit: "USD", Value: 2500}).
+		QuantityFor(nil, 10).
+		QuantityFor(map[string]string{"Size": "XL", "Color": "Red"}, 0)
+}
+
+func TestVariantMatrix(t *testing.T) {
+	matrix := newMatrix()
+
+	properties := matrix.Properties()
+	variants := matrix.Variants()
+
+	assert.Len(t, properties, 2)
+	assert.Equal(t, "Size", properties[0].Name)
+	assert.Equal(t, "navy-blue", properties[1].Enum[1].Slug)
+	assert.Equal(t, properties, newMatrix().Properties())
+
+	names := make([]string, len(variants))
+	for i, variant := range variants {
+		names[i] = variant.Fields.Name
+	}
+	assert.Equal(t, []string{
+		"T-Shirt S Red",
+		"T-Shirt S Navy Blue",
+		"T-Shirt M Red",
+		"T-Shirt M Navy Blue",
+		"T-Shirt XL Red",
+		"T-Shirt XL Navy Blue",
+	}, names)
+
+	assert.Equal(t, "t-shirt-s-navy-blue", variants[1].Fields.Slug)
+	assert.Equal(t, map[string]string{
+		properties[0].ID: properties[0].Enum[0].ID,
+		properties[1].ID: properties[1].Enum[1].ID,
+	}, variants[1].Fields.SKUValues)
+
+	assert.Equal(t, int64(2000), variants[0].Fields.Price.Value)
+	assert.Equal(t, int64(2500), variants[5].Fields.Price.Value)
+	assert.Equal(t, 10, *variants[0].Quantity)
+	assert.Equal(t, 0, *variants[4].Quantity)
+}
+
+type fakeProduct struct {
+	product.Product
+	updated     []string
+	created     [][]string
+	rateLimited int
+}
+
+func (f *fakeProduct) UpdateSKUWithContext(ctx context.Context, siteID string, productID string, skuID string, req model.UpdateSKURequest) (*model.SKU, *common.Error) {
+	f.updated = append(f.updated, req.SKU.Name)
+	return &model.SKU{ID: skuID, Name: req.SKU.Name}, nil
+}
+
+func (f *fakeProduct) CreateSKUsWithContext(ctx context.Context, siteID string, productID string, req model.CreateSKUsRequest) ([]model.SKU, *common.Error) {
+	if f.rateLimited > 0 {
+		f.rateLimited--
+		return nil, &common.Error{Code: http.StatusTooManyRequests, Err: "Too Many Requests"}
+	}
+
+	names := make([]string, len(req.SKUs))
+	skus := make([]model.SKU, len(req.SKUs))
+	for i, sku := range req.SKUs {
+		names[i] = sku.Name
+		skus[i] = model.SKU{Name: sku.Name}
+	}
+	f.created = append(f.created, names)
+
+	return skus, nil
+}
+
+func TestSubmitVariants(t *testing.T) {
+	variants := newMatrix().Variants()
+
+	testcases := []struct {
+		desc            string
+		rateLimited     int
+		opts            product.SubmitOptions
+		expectedUpdated []string
+		expectedCreated [][]string
+		expectedCount   int
+		expectedErrCode int
+	}{
+		{
+			desc:            "should update default sku and create the rest in batches",
+			opts:            product.SubmitOptions{DefaultSKUID: "5eb9fd05caef491eb9757187", BatchSize: 2},
+			expectedUpdated: []string{"T-Shirt S Red"},
+			expectedCreated: [][]string{
+				{"T-Shirt S Navy Blue", "T-Shirt M Red"},
+				{"T-Shirt M Navy Blue", "T-Shirt XL Red"},
+				{"T-Shirt XL Navy Blue"},
+			},
+			expectedCount: 6,
+		},
+		{
+			desc:        "should retry rate limited batch",
+			rateLimited: 2,
+			opts:        product.SubmitOptions{BatchSize: 6, RetryWait: time.Millisecond, Limiter: common.NewLimiter(60000)},
+			expectedCreated: [][]string{
+				{"T-Shirt S Red", "T-Shirt S Navy Blue", "T-Shirt M Red", "T-Shirt M Navy Blue", "T-Shirt XL Red", "T-Shirt XL Navy Blue"},
+			},
+			expectedCount: 6,
+		},
+		{
+			desc:            "should give up after max retries",
+			rateLimited:     2,
+			opts:            product.SubmitOptions{MaxRetries: 1, RetryWait: time.Millisecond},
+			expectedCount:   0,
+			expectedErrCode: http.StatusTooManyRequests,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			service := &fakeProduct{rateLimited: tc.rateLimited}
+
+			skus, err := product.SubmitVariants(context.Background(), service, "580e63e98c9a982ac9b8b741", productID, variants, tc.opts)
+
+			assert.Len(t, skus, tc.expectedCount)
+			assert.Equal(t, tc.expectedUpdated, service.updated)
+			assert.Equal(t, tc.expectedCreated, service.created)
+			if tc.expectedErrCode == 0 {
+				assert.Nil(t, err)
+			} else {
+				assert.Equal(t, tc.expectedErrCode, err.Code)
+			}
+		})
+	}
+}
+
+func TestSubmitVariantsInventoryAndProperties(t *testing.T) {
+	siteID := "580e63e98c9a982ac9b8b741"
+	srv := webflowtest.NewServer(&webflowtest.Fixtures{Sites: []model.Site{{ID: siteID}}})
+	defer srv.Close()
+	wf := srv.Webflow()
+
+	matrix := newMatrix()
+	variants := matrix.Variants()
+	created, err := wf.Product.Create(siteID, model.CreateProductRequest{
+		Product: model.ProductFields{Name: "T-Shirt", Slug: "t-shirt"},
+		SKU:     variants[0].Fields,
+	})
+	assert.Nil(t, err)
+
+	skus, err := product.SubmitVariants(context.Background(), wf.Product, siteID, created.Product.ID, variants, product.SubmitOptions{
+		DefaultSKUID: created.SKU.ID,
+		BatchSize:    4,
+		Properties:   matrix.Properties(),
+		Inventory:    wf.Inventory,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, skus, 6)
+
+	current, err := wf.Product.Get(siteID, created.Product.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, matrix.Properties(), current.Product.SKUProperties)
+
+	quantities := make([]int, len(skus))
+	for i, sku := range skus {
+		inventory, err := wf.Inventory.Get(sku.CollectionID, sku.ID)
+		assert.Nil(t, err)
+		assert.Equal(t, model.InventoryTypeFinite, inventory.InventoryType)
+		quantities[i] = inventory.Quantity
+	}
+	assert.Equal(t, []int{10, 10, 10, 10, 0, 10}, quantities)
+}
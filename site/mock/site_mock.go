@@ -0,0 +1,49 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/nasrul21/go-webflow/site"
+	"github.com/stretchr/testify/mock"
+)
+
+// SiteMock is a testify mock of site.Site.
+type SiteMock struct {
+	mock.Mock
+}
+
+var _ site.Site = (*SiteMock)(nil)
+
+func (m *SiteMock) GetList() ([]model.Site, *common.Error) {
+	args := m.Called()
+	res, _ := args.Get(0).([]model.Site)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *SiteMock) GetListWithContext(ctx context.Context) ([]model.Site, *common.Error) {
+	args := m.Called(ctx)
+	res, _ := args.Get(0).([]model.Site)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *SiteMock) Publish(siteID string, req model.PublishSiteRequest) (*model.PublishSiteResponse, *common.Error) {
+	args := m.Called(siteID, req)
+	res, _ := args.Get(0).(*model.PublishSiteResponse)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
+
+func (m *SiteMock) PublishWithContext(ctx context.Context, siteID string, req model.PublishSiteRequest) (*model.PublishSiteResponse, *common.Error) {
+	args := m.Called(ctx, siteID, req)
+	res, _ := args.Get(0).(*model.PublishSiteResponse)
+	err, _ := args.Get(1).(*common.Error)
+
+	return res, err
+}
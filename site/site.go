@@ -0,0 +1,80 @@
+package site
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/nasrul21/go-webflow/client"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+)
+
+type Site interface {
+	GetList() ([]model.Site, *common.Error)
+	GetListWithContext(ctx context.Context) ([]model.Site, *common.Error)
+	Publish(siteID string, req model.PublishSiteRequest) (*model.PublishSiteResponse, *common.Error)
+	PublishWithContext(ctx context.Context, siteID string, req model.PublishSiteRequest) (*model.PublishSiteResponse, *common.Error)
+}
+
+type SiteImpl struct {
+	Opt    *common.Option
+	Client client.Client
+}
+
+func New(opt *common.Option, client client.Client) Site {
+	return &SiteImpl{
+		Opt:    opt,
+		Client: client,
+	}
+}
+
+func (s *SiteImpl) GetList() ([]model.Site, *common.Error) {
+	return s.GetListWithContext(context.Background())
+}
+
+func (s *SiteImpl) GetListWithContext(ctx context.Context) ([]model.Site, *common.Error) {
+	response := []model.Site{}
+	var header http.Header
+
+	err := s.Client.Call(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/sites", s.Opt.BaseURL),
+		s.Opt.ApiKey,
+		header,
+		nil,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Publish queues a publish of the site to the given domains. The API takes
+// the domain names, not their IDs.
+func (s *SiteImpl) Publish(siteID string, req model.PublishSiteRequest) (*model.PublishSiteResponse, *common.Error) {
+	return s.PublishWithContext(context.Background(), siteID, req)
+}
+
+func (s *SiteImpl) PublishWithContext(ctx context.Context, siteID string, req model.PublishSiteRequest) (*model.PublishSiteResponse, *common.Error) {
+	response := model.PublishSiteResponse{}
+	var header http.Header
+
+	err := s.Client.Call(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("%s/sites/%s/publish", s.Opt.BaseURL, siteID),
+		s.Opt.ApiKey,
+		header,
+		req,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
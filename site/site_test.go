@@ -0,0 +1,171 @@
+package site_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nasrul21/go-webflow"
+	"github.com/nasrul21/go-webflow/client/mock"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetList(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		resultString := `[
+			{
+				"_id": "580e63e98c9a982ac9b8b741",
+				"createdOn": "2016-10-24T19:41:29.156Z",
+				"name": "api_docs_sample_json",
+				"shortName": "api-docs-sample-json",
+				"lastPublished": "2016-10-24T19:43:17.271Z",
+				"previewUrl": "https://screenshots.webflow.com/sites/580e63e98c9a982ac9b8b741/20161024194317.png",
+				"timezone": "America/Los_Angeles",
+				"database": "580e63fc8c9a982ac9b8b744"
+			}
+		]`
+
+		_ = json.Unmarshal([]byte(resultString), &result)
+
+		return nil
+	}
+
+	testcases := []struct {
+		desc        string
+		mockClosure func()
+		expectedRes []model.Site
+		expectedErr *common.Error
+	}{
+		{
+			desc: "should get list sites",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/sites", wf.Opt.BaseURL),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&[]model.Site{},
+				).Return(nil).Once()
+			},
+			expectedRes: []model.Site{
+				{
+					ID:            "580e63e98c9a982ac9b8b741",
+					CreatedOn:     time.Date(2016, 10, 24, 19, 41, 29, int(156*time.Millisecond), time.UTC),
+					Name:          "api_docs_sample_json",
+					ShortName:     "api-docs-sample-json",
+					LastPublished: time.Date(2016, 10, 24, 19, 43, 17, int(271*time.Millisecond), time.UTC),
+					PreviewURL:    "https://screenshots.webflow.com/sites/580e63e98c9a982ac9b8b741/20161024194317.png",
+					Timezone:      "America/Los_Angeles",
+					Database:      "580e63fc8c9a982ac9b8b744",
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "should return error",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodGet,
+					fmt.Sprintf("%s/sites", wf.Opt.BaseURL),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					nil,
+					&[]model.Site{},
+				).Return(common.FromGoErr(fmt.Errorf("some error"))).Once()
+			},
+			expectedRes: nil,
+			expectedErr: common.FromGoErr(fmt.Errorf("some error")),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.mockClosure()
+
+			resp, err := wf.Site.GetList()
+
+			assert.Equal(t, tc.expectedRes, resp)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestPublish(t *testing.T) {
+	httpClientMockObj := new(mock.ClientMock)
+	wf := webflow.New("apikey_123").WithHttpClient(httpClientMockObj)
+
+	httpClientMockObj.CallFunc = func(result interface{}) *common.Error {
+		_ = json.Unmarshal([]byte(`{"queued": true}`), &result)
+
+		return nil
+	}
+
+	siteID := "580e63e98c9a982ac9b8b741"
+	req := model.PublishSiteRequest{Domains: []string{"test-api-domain.com"}}
+
+	testcases := []struct {
+		desc        string
+		mockClosure func()
+		expectedRes *model.PublishSiteResponse
+		expectedErr *common.Error
+	}{
+		{
+			desc: "should publish site",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodPost,
+					fmt.Sprintf("%s/sites/%s/publish", wf.Opt.BaseURL, siteID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					req,
+					&model.PublishSiteResponse{},
+				).Return(nil).Once()
+			},
+			expectedRes: &model.PublishSiteResponse{Queued: true},
+			expectedErr: nil,
+		},
+		{
+			desc: "should return error",
+			mockClosure: func() {
+				httpClientMockObj.On(
+					"Call",
+					context.Background(),
+					http.MethodPost,
+					fmt.Sprintf("%s/sites/%s/publish", wf.Opt.BaseURL, siteID),
+					wf.Opt.ApiKey,
+					http.Header(nil),
+					req,
+					&model.PublishSiteResponse{},
+				).Return(common.FromGoErr(fmt.Errorf("some error"))).Once()
+			},
+			expectedRes: nil,
+			expectedErr: common.FromGoErr(fmt.Errorf("some error")),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.mockClosure()
+
+			resp, err := wf.Site.Publish(siteID, req)
+
+			assert.Equal(t, tc.expectedRes, resp)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
@@ -3,10 +3,17 @@ package webflow
 import (
 	"net/http"
 
+	"github.com/nasrul21/go-webflow/asset"
 	"github.com/nasrul21/go-webflow/client"
+	"github.com/nasrul21/go-webflow/collection"
 	"github.com/nasrul21/go-webflow/common"
 	"github.com/nasrul21/go-webflow/domain"
+	"github.com/nasrul21/go-webflow/ecommerce"
+	"github.com/nasrul21/go-webflow/inventory"
+	"github.com/nasrul21/go-webflow/item"
 	"github.com/nasrul21/go-webflow/meta"
+	"github.com/nasrul21/go-webflow/order"
+	"github.com/nasrul21/go-webflow/product"
 	"github.com/nasrul21/go-webflow/site"
 )
 
@@ -16,12 +23,26 @@ type Webflow struct {
 	Meta       meta.Meta
 	Domain     domain.Domain
 	Site       site.Site
+	Collection collection.Collection
+	Item       item.Item
+	Asset      asset.Asset
+	Product    product.Product
+	Order      order.Order
+	Inventory  inventory.Inventory
+	Ecommerce  ecommerce.Ecommerce
 }
 
 func (w *Webflow) init() {
 	w.Meta = meta.New(&w.Opt, w.httpClient)
 	w.Domain = domain.New(&w.Opt, w.httpClient)
 	w.Site = site.New(&w.Opt, w.httpClient)
+	w.Collection = collection.New(&w.Opt, w.httpClient)
+	w.Item = item.New(&w.Opt, w.httpClient)
+	w.Asset = asset.New(&w.Opt, w.httpClient)
+	w.Product = product.New(&w.Opt, w.httpClient)
+	w.Order = order.New(&w.Opt, w.httpClient)
+	w.Inventory = inventory.New(&w.Opt, w.httpClient)
+	w.Ecommerce = ecommerce.New(&w.Opt, w.httpClient)
 }
 
 func New(apiKey string) *Webflow {
@@ -0,0 +1,322 @@
+package webflowtest
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/nasrul21/go-webflow/model"
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// reservedItemFields are accepted on every collection, whatever its schema.
+var reservedItemFields = map[string]bool{
+	"_archived": true, "_draft": true, "name": true, "slug": true,
+}
+
+func (s *Server) listCollections(r *http.Request, params map[string]string, body []byte) response {
+	if s.data.site(params["site"]) == nil {
+		return notFound(r)
+	}
+
+	// The list endpoint leaves out the schema, like the real API.
+	collections := []model.Collection{}
+	for _, collection := range s.data.Collections[params["site"]] {
+		collection.Fields = nil
+		collections = append(collections, collection)
+	}
+
+	return ok(collections)
+}
+
+func (s *Server) getCollection(r *http.Request, params map[string]string, body []byte) response {
+	collection, _ := s.data.collection(params["collection"])
+	if collection == nil {
+		return notFound(r)
+	}
+
+	return ok(collection)
+}
+
+func (s *Server) listItems(r *http.Request, params map[string]string, body []byte) response {
+	collection, _ := s.data.collection(params["collection"])
+	if collection == nil {
+		return notFound(r)
+	}
+
+	offset, limit, problems := pagination(r)
+	if len(problems) > 0 {
+		return validationError(r, problems...)
+	}
+
+	items := s.data.Items[collection.ID]
+	start, end := bounds(offset, limit, len(items))
+
+	return ok(model.ItemList{
+		Items:  append([]model.Item{}, items[start:end]...),
+		Count:  end - start,
+		Limit:  limit,
+		Offset: offset,
+		Total:  len(items),
+	})
+}
+
+func (s *Server) findItem(collectionID string, itemID string) int {
+	for i, item := range s.data.Items[collectionID] {
+		if item.ID == itemID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (s *Server) getItem(r *http.Request, params map[string]string, body []byte) response {
+	collection, _ := s.data.collection(params["collection"])
+	if collection == nil {
+		return notFound(r)
+	}
+	index := s.findItem(collection.ID, params["item"])
+	if index < 0 {
+		return notFound(r)
+	}
+
+	return ok(model.ItemList{
+		Items: []model.Item{s.data.Items[collection.ID][index]},
+		Count: 1,
+		Limit: 1,
+		Total: 1,
+	})
+}
+
+func (s *Server) createItem(r *http.Request, params map[string]string, body []byte) response {
+	collection, siteID := s.data.collection(params["collection"])
+	if collection == nil {
+		return notFound(r)
+	}
+
+	var req struct {
+		Fields map[string]interface{} `json:"fields"`
+	}
+	if res := decodeBody(r, body, &req); res != nil {
+		return *res
+	}
+	if problems := s.validateItem(collection, "", req.Fields); len(problems) > 0 {
+		return validationError(r, problems...)
+	}
+
+	item := itemFromFields(req.Fields)
+	item.ID = s.id()
+	item.CollectionID = collection.ID
+	item.CreatedOn = s.now()
+	item.UpdatedOn = item.CreatedOn
+	if r.URL.Query().Get("live") == "true" {
+		item.PublishedOn = item.CreatedOn
+	}
+	s.data.Items[collection.ID] = append(s.data.Items[collection.ID], item)
+
+	res := ok(item)
+	res.deliveries = s.trigger(siteID, model.TriggerCollectionItemCreated, item)
+
+	return res
+}
+
+func (s *Server) replaceItem(r *http.Request, params map[string]string, body []byte) response {
+	return s.updateItem(r, params, body, true)
+}
+
+func (s *Server) patchItem(r *http.Request, params map[string]string, body []byte) response {
+	return s.updateItem(r, params, body, false)
+}
+
+// updateItem replaces every field of the item on PUT and only the given
+// fields on PATCH.
+func (s *Server) updateItem(r *http.Request, params map[string]string, body []byte, replace bool) response {
+	collection, siteID := s.data.collection(params["collection"])
+	if collection == nil {
+		return notFound(r)
+	}
+	index := s.findItem(collection.ID, params["item"])
+	if index < 0 {
+		return notFound(r)
+	}
+	current := s.data.Items[collection.ID][index]
+
+	var req struct {
+		Fields map[string]interface{} `json:"fields"`
+	}
+	if res := decodeBody(r, body, &req); res != nil {
+		return *res
+	}
+
+	fields := req.Fields
+	if !replace {
+		fields = itemFields(current)
+		for slug, value := range req.Fields {
+			fields[slug] = value
+		}
+	}
+	if problems := s.validateItem(collection, current.ID, fields); len(problems) > 0 {
+		return validationError(r, problems...)
+	}
+
+	item := itemFromFields(fields)
+	item.ID = current.ID
+	item.CollectionID = current.CollectionID
+	item.CreatedOn = current.CreatedOn
+	item.PublishedOn = current.PublishedOn
+	item.UpdatedOn = s.now()
+	if r.URL.Query().Get("live") == "true" {
+		item.PublishedOn = item.UpdatedOn
+	}
+	s.data.Items[collection.ID][index] = item
+
+	res := ok(item)
+	res.deliveries = s.trigger(siteID, model.TriggerCollectionItemChanged, item)
+
+	return res
+}
+
+func (s *Server) deleteItem(r *http.Request, params map[string]string, body []byte) response {
+	collection, siteID := s.data.collection(params["collection"])
+	if collection == nil {
+		return notFound(r)
+	}
+	index := s.findItem(collection.ID, params["item"])
+	if index < 0 {
+		return notFound(r)
+	}
+
+	items := s.data.Items[collection.ID]
+	s.data.Items[collection.ID] = append(items[:index:index], items[index+1:]...)
+
+	deleted := model.CollectionItemDeleted{Deleted: 1, ItemID: params["item"]}
+	res := ok(map[string]int{"deleted": 1})
+	res.deliveries = s.trigger(siteID, model.TriggerCollectionItemDeleted, deleted)
+
+	return res
+}
+
+// validateItem checks fields against the collection schema and returns the
+// problems in the wording of the real API.
+func (s *Server) validateItem(collection *model.Collection, itemID string, fields map[string]interface{}) []string {
+	if fields == nil {
+		return []string{"Field 'fields': Field is required"}
+	}
+
+	var problems []string
+	for _, slug := range []string{"name", "slug"} {
+		if v, _ := fields[slug].(string); v == "" {
+			problems = append(problems, fmt.Sprintf("Field '%s': Field is required", slug))
+		}
+	}
+	if slug, _ := fields["slug"].(string); slug != "" {
+		if !slugPattern.MatchString(slug) {
+			problems = append(problems, fmt.Sprintf("Field 'slug': Invalid slug '%s'", slug))
+		}
+		for _, item := range s.data.Items[collection.ID] {
+			if item.Slug == slug && item.ID != itemID {
+				problems = append(problems, fmt.Sprintf("Field 'slug': Unique value is already in database: '%s'", slug))
+			}
+		}
+	}
+
+	for slug, value := range fields {
+		if reservedItemFields[slug] {
+			continue
+		}
+
+		field := collection.Field(slug)
+		if field == nil {
+			problems = append(problems, fmt.Sprintf("Field '%s': Field not described in schema", slug))
+			continue
+		}
+		if value == nil {
+			continue
+		}
+		if problem := validateFieldValue(field, value); problem != "" {
+			problems = append(problems, fmt.Sprintf("Field '%s': %s", slug, problem))
+		}
+	}
+
+	for _, field := range collection.Fields {
+		if !field.Required || reservedItemFields[field.Slug] {
+			continue
+		}
+		if value, ok := fields[field.Slug]; !ok || value == nil || value == "" {
+			problems = append(problems, fmt.Sprintf("Field '%s': Field is required", field.Slug))
+		}
+	}
+	sort.Strings(problems)
+
+	return problems
+}
+
+func validateFieldValue(field *model.CollectionField, value interface{}) string {
+	switch field.Type {
+	case model.FieldTypePlainText, model.FieldTypeRichText, model.FieldTypeLink, model.FieldTypeEmail,
+		model.FieldTypePhone, model.FieldTypeColor, model.FieldTypeOption, model.FieldTypeItemRef:
+		if _, ok := value.(string); !ok {
+			return "Expected a string"
+		}
+	case model.FieldTypeNumber:
+		if _, ok := value.(float64); !ok {
+			return "Expected a number"
+		}
+	case model.FieldTypeBool:
+		if _, ok := value.(bool); !ok {
+			return "Expected a boolean"
+		}
+	case model.FieldTypeDate:
+		text, ok := value.(string)
+		if !ok {
+			return "Expected a date"
+		}
+		if _, err := time.Parse(time.RFC3339, text); err != nil {
+			return "Expected a date"
+		}
+	case model.FieldTypeItemRefSet:
+		if _, ok := value.([]interface{}); !ok {
+			return "Expected a list of item IDs"
+		}
+	}
+
+	return ""
+}
+
+func itemFromFields(fields map[string]interface{}) model.Item {
+	item := model.Item{Fields: map[string]interface{}{}}
+	for slug, value := range fields {
+		switch slug {
+		case "name":
+			item.Name, _ = value.(string)
+		case "slug":
+			item.Slug, _ = value.(string)
+		case "_archived":
+			item.Archived, _ = value.(bool)
+		case "_draft":
+			item.Draft, _ = value.(bool)
+		default:
+			item.Fields[slug] = value
+		}
+	}
+
+	return item
+}
+
+func itemFields(item model.Item) map[string]interface{} {
+	fields := map[string]interface{}{
+		"name":      item.Name,
+		"slug":      item.Slug,
+		"_archived": item.Archived,
+		"_draft":    item.Draft,
+	}
+	for slug, value := range item.Fields {
+		fields[slug] = value
+	}
+
+	return fields
+}
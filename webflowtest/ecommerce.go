@@ -0,0 +1,532 @@
+package webflowtest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/nasrul21/go-webflow/model"
+)
+
+func (s *Server) findProduct(siteID string, productID string) *model.ProductWithSKUs {
+	products := s.data.Products[siteID]
+	for i := range products {
+		if products[i].Product.ID == productID {
+			return &products[i]
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) listProducts(r *http.Request, params map[string]string, body []byte) response {
+	if s.data.site(params["site"]) == nil {
+		return notFound(r)
+	}
+
+	offset, limit, problems := pagination(r)
+	if len(problems) > 0 {
+		return validationError(r, problems...)
+	}
+
+	products := s.data.Products[params["site"]]
+	start, end := bounds(offset, limit, len(products))
+
+	return ok(model.ProductList{
+		Items:  append([]model.ProductWithSKUs{}, products[start:end]...),
+		Count:  end - start,
+		Limit:  limit,
+		Offset: offset,
+		Total:  len(products),
+	})
+}
+
+func (s *Server) getProduct(r *http.Request, params map[string]string, body []byte) response {
+	product := s.findProduct(params["site"], params["product"])
+	if product == nil {
+		return notFound(r)
+	}
+
+	return ok(product)
+}
+
+func (s *Server) createProduct(r *http.Request, params map[string]string, body []byte) response {
+	siteID := params["site"]
+	if s.data.site(siteID) == nil {
+		return notFound(r)
+	}
+
+	var req struct {
+		Product struct {
+			Fields model.ProductFields `json:"fields"`
+		} `json:"product"`
+		SKU struct {
+			Fields model.SKUFields `json:"fields"`
+		} `json:"sku"`
+	}
+	if res := decodeBody(r, body, &req); res != nil {
+		return *res
+	}
+
+	fields := req.Product.Fields
+	problems := s.validateProduct(siteID, "", fields)
+	problems = append(problems, validateSKU("sku.", req.SKU.Fields)...)
+	if len(problems) > 0 {
+		return validationError(r, problems...)
+	}
+
+	product := model.Product{
+		ID:            s.id(),
+		Archived:      isTrue(fields.Archived),
+		Draft:         isTrue(fields.Draft),
+		Name:          fields.Name,
+		Slug:          fields.Slug,
+		Description:   fields.Description,
+		TaxCategory:   fields.TaxCategory,
+		ProductType:   fields.ProductType,
+		SKUProperties: fields.SKUProperties,
+		CreatedOn:     s.now(),
+	}
+	product.UpdatedOn = product.CreatedOn
+	if fields.Shippable != nil {
+		product.Shippable = *fields.Shippable
+	}
+
+	sku := s.newSKU(siteID, product, req.SKU.Fields)
+	product.DefaultSKU = sku.ID
+
+	s.data.Products[siteID] = append(s.data.Products[siteID], model.ProductWithSKUs{Product: product, SKUs: []model.SKU{sku}})
+
+	return ok(model.ProductWithDefaultSKU{Product: product, SKU: sku})
+}
+
+func (s *Server) updateProduct(r *http.Request, params map[string]string, body []byte) response {
+	current := s.findProduct(params["site"], params["product"])
+	if current == nil {
+		return notFound(r)
+	}
+
+	var req struct {
+		Fields model.ProductFields `json:"fields"`
+	}
+	if res := decodeBody(r, body, &req); res != nil {
+		return *res
+	}
+
+	fields := req.Fields
+	if fields.Name == "" {
+		fields.Name = current.Product.Name
+	}
+	if fields.Slug == "" {
+		fields.Slug = current.Product.Slug
+	}
+	if problems := s.validateProduct(params["site"], current.Product.ID, fields); len(problems) > 0 {
+		return validationError(r, problems...)
+	}
+
+	product := &current.Product
+	if fields.Archived != nil {
+		product.Archived = *fields.Archived
+	}
+	if fields.Draft != nil {
+		product.Draft = *fields.Draft
+	}
+	product.Name = fields.Name
+	product.Slug = fields.Slug
+	if fields.Description != "" {
+		product.Description = fields.Description
+	}
+	if fields.Shippable != nil {
+		product.Shippable = *fields.Shippable
+	}
+	if fields.TaxCategory != "" {
+		product.TaxCategory = fields.TaxCategory
+	}
+	if fields.ProductType != "" {
+		product.ProductType = fields.ProductType
+	}
+	if fields.SKUProperties != nil {
+		product.SKUProperties = fields.SKUProperties
+	}
+	product.UpdatedOn = s.now()
+
+	return ok(product)
+}
+
+func (s *Server) createSKUs(r *http.Request, params map[string]string, body []byte) response {
+	current := s.findProduct(params["site"], params["product"])
+	if current == nil {
+		return notFound(r)
+	}
+
+	var req struct {
+		SKUs []struct {
+			Fields model.SKUFields `json:"fields"`
+		} `json:"skus"`
+	}
+	if res := decodeBody(r, body, &req); res != nil {
+		return *res
+	}
+	if len(req.SKUs) == 0 {
+		return validationError(r, "Field 'skus': Field is required")
+	}
+
+	var problems []string
+	for i, sku := range req.SKUs {
+		problems = append(problems, validateSKU(fmt.Sprintf("skus[%d].", i), sku.Fields)...)
+	}
+	if len(problems) > 0 {
+		return validationError(r, problems...)
+	}
+
+	list := model.SKUList{}
+	for _, sku := range req.SKUs {
+		created := s.newSKU(params["site"], current.Product, sku.Fields)
+		current.SKUs = append(current.SKUs, created)
+		list.SKUs = append(list.SKUs, created)
+	}
+
+	return ok(list)
+}
+
+func (s *Server) updateSKU(r *http.Request, params map[string]string, body []byte) response {
+	current := s.findProduct(params["site"], params["product"])
+	if current == nil {
+		return notFound(r)
+	}
+
+	var sku *model.SKU
+	for i := range current.SKUs {
+		if current.SKUs[i].ID == params["sku"] {
+			sku = &current.SKUs[i]
+		}
+	}
+	if sku == nil {
+		return notFound(r)
+	}
+
+	var req struct {
+		SKU struct {
+			Fields model.SKUFields `json:"fields"`
+		} `json:"sku"`
+	}
+	if res := decodeBody(r, body, &req); res != nil {
+		return *res
+	}
+
+	fields := req.SKU.Fields
+	if fields.Price != nil {
+		if problems := validateSKU("sku.", fields); len(problems) > 0 {
+			return validationError(r, problems...)
+		}
+		sku.Price = *fields.Price
+	}
+	if fields.Archived != nil {
+		sku.Archived = *fields.Archived
+	}
+	if fields.Draft != nil {
+		sku.Draft = *fields.Draft
+	}
+	if fields.Name != "" {
+		sku.Name = fields.Name
+	}
+	if fields.Slug != "" {
+		sku.Slug = fields.Slug
+	}
+	if fields.SKUValues != nil {
+		sku.SKUValues = fields.SKUValues
+	}
+	if fields.CompareAtPrice != nil {
+		sku.CompareAtPrice = fields.CompareAtPrice
+	}
+	if fields.SKU != "" {
+		sku.SKU = fields.SKU
+	}
+	sku.UpdatedOn = s.now()
+
+	return ok(sku)
+}
+
+func (s *Server) validateProduct(siteID string, productID string, fields model.ProductFields) []string {
+	var problems []string
+	if fields.Name == "" {
+		problems = append(problems, "Field 'product.name': Field is required")
+	}
+	if fields.Slug == "" {
+		problems = append(problems, "Field 'product.slug': Field is required")
+	} else if !slugPattern.MatchString(fields.Slug) {
+		problems = append(problems, fmt.Sprintf("Field 'product.slug': Invalid slug '%s'", fields.Slug))
+	}
+	for _, product := range s.data.Products[siteID] {
+		if fields.Slug != "" && product.Product.Slug == fields.Slug && product.Product.ID != productID {
+			problems = append(problems, fmt.Sprintf("Field 'product.slug': Unique value is already in database: '%s'", fields.Slug))
+		}
+	}
+
+	return problems
+}
+
+func validateSKU(prefix string, fields model.SKUFields) []string {
+	var problems []string
+	if fields.Price == nil {
+		return append(problems, fmt.Sprintf("Field '%sprice': Field is required", prefix))
+	}
+	if fields.Price.Unit == "" {
+		problems = append(problems, fmt.Sprintf("Field '%sprice.unit': Field is required", prefix))
+	}
+	if fields.Price.Value < 0 {
+		problems = append(problems, fmt.Sprintf("Field '%sprice.value': Must be a positive number", prefix))
+	}
+	if fields.CompareAtPrice != nil && fields.CompareAtPrice.Unit != fields.Price.Unit {
+		problems = append(problems, fmt.Sprintf("Field '%scompare-at-price.unit': Must match price unit", prefix))
+	}
+
+	return problems
+}
+
+func (s *Server) newSKU(siteID string, product model.Product, fields model.SKUFields) model.SKU {
+	sku := model.SKU{
+		ID:             s.id(),
+		CollectionID:   s.skuCollection(siteID),
+		Archived:       isTrue(fields.Archived),
+		Draft:          isTrue(fields.Draft),
+		Name:           fields.Name,
+		Slug:           fields.Slug,
+		Product:        product.ID,
+		SKUValues:      fields.SKUValues,
+		Price:          *fields.Price,
+		CompareAtPrice: fields.CompareAtPrice,
+		MainImage:      fields.MainImage,
+		MoreImages:     fields.MoreImages,
+		SKU:            fields.SKU,
+		Width:          fields.Width,
+		Length:         fields.Length,
+		Height:         fields.Height,
+		Weight:         fields.Weight,
+		CreatedOn:      s.now(),
+	}
+	sku.UpdatedOn = sku.CreatedOn
+	if sku.Name == "" {
+		sku.Name = product.Name
+	}
+	if sku.Slug == "" {
+		sku.Slug = fmt.Sprintf("%s-%s", product.Slug, sku.ID[len(sku.ID)-6:])
+	}
+
+	return sku
+}
+
+func (s *Server) findOrder(siteID string, orderID string) *model.Order {
+	orders := s.data.Orders[siteID]
+	for i := range orders {
+		if orders[i].OrderID == orderID {
+			return &orders[i]
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) listOrders(r *http.Request, params map[string]string, body []byte) response {
+	if s.data.site(params["site"]) == nil {
+		return notFound(r)
+	}
+
+	offset, limit, problems := pagination(r)
+	if len(problems) > 0 {
+		return validationError(r, problems...)
+	}
+
+	status := r.URL.Query().Get("status")
+	orders := []model.Order{}
+	for _, order := range s.data.Orders[params["site"]] {
+		if status == "" || order.Status == status {
+			orders = append(orders, order)
+		}
+	}
+	start, end := bounds(offset, limit, len(orders))
+
+	return ok(orders[start:end])
+}
+
+func (s *Server) getOrder(r *http.Request, params map[string]string, body []byte) response {
+	order := s.findOrder(params["site"], params["order"])
+	if order == nil {
+		return notFound(r)
+	}
+
+	return ok(order)
+}
+
+func (s *Server) updateOrder(r *http.Request, params map[string]string, body []byte) response {
+	order := s.findOrder(params["site"], params["order"])
+	if order == nil {
+		return notFound(r)
+	}
+
+	var req struct {
+		Fields *model.UpdateOrderRequest `json:"fields"`
+	}
+	if res := decodeBody(r, body, &req); res != nil {
+		return *res
+	}
+	if req.Fields == nil {
+		return validationError(r, "Field 'fields': Field is required")
+	}
+
+	if req.Fields.Comment != "" {
+		order.Comment = req.Fields.Comment
+	}
+	if req.Fields.ShippingProvider != "" {
+		order.ShippingProvider = req.Fields.ShippingProvider
+	}
+	if req.Fields.ShippingTracking != "" {
+		order.ShippingTracking = req.Fields.ShippingTracking
+	}
+
+	res := ok(order)
+	res.deliveries = s.trigger(params["site"], model.TriggerEcommOrderChanged, order)
+
+	return res
+}
+
+func (s *Server) fulfillOrder(r *http.Request, params map[string]string, body []byte) response {
+	return s.transitionOrder(r, params, []string{model.OrderStatusUnfulfilled}, model.OrderStatusFulfilled)
+}
+
+func (s *Server) unfulfillOrder(r *http.Request, params map[string]string, body []byte) response {
+	return s.transitionOrder(r, params, []string{model.OrderStatusFulfilled}, model.OrderStatusUnfulfilled)
+}
+
+func (s *Server) refundOrder(r *http.Request, params map[string]string, body []byte) response {
+	return s.transitionOrder(r, params, []string{model.OrderStatusUnfulfilled, model.OrderStatusFulfilled}, model.OrderStatusRefunded)
+}
+
+// transitionOrder moves an order to status when it is in one of from and
+// answers 409 otherwise, as the real API does.
+func (s *Server) transitionOrder(r *http.Request, params map[string]string, from []string, status string) response {
+	order := s.findOrder(params["site"], params["order"])
+	if order == nil {
+		return notFound(r)
+	}
+
+	allowed := false
+	for _, f := range from {
+		if order.Status == f {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return errorResponse(r, http.StatusConflict, "Conflict", fmt.Sprintf("Order %s cannot be %s from status %s", order.OrderID, status, order.Status))
+	}
+
+	order.Status = status
+	switch status {
+	case model.OrderStatusFulfilled:
+		order.FulfilledOn = s.now()
+	case model.OrderStatusUnfulfilled:
+		order.FulfilledOn = nil
+	case model.OrderStatusRefunded:
+		order.RefundedOn = s.now()
+	}
+
+	res := ok(order)
+	res.deliveries = s.trigger(params["site"], model.TriggerEcommOrderChanged, order)
+
+	return res
+}
+
+// skuCollection returns the ID of the collection holding the SKUs of a site,
+// taken from its existing SKUs.
+func (s *Server) skuCollection(siteID string) string {
+	for _, product := range s.data.Products[siteID] {
+		for _, sku := range product.SKUs {
+			if sku.CollectionID != "" {
+				return sku.CollectionID
+			}
+		}
+	}
+
+	return s.id()
+}
+
+// findSKU returns the SKU of a SKU collection and the ID of its site.
+func (s *Server) findSKU(collectionID string, skuID string) (*model.SKU, string) {
+	for siteID, products := range s.data.Products {
+		for _, product := range products {
+			for i := range product.SKUs {
+				if product.SKUs[i].ID == skuID && product.SKUs[i].CollectionID == collectionID {
+					return &product.SKUs[i], siteID
+				}
+			}
+		}
+	}
+
+	return nil, ""
+}
+
+func (s *Server) inventory(skuID string) model.Inventory {
+	if inventory, ok := s.data.Inventory[skuID]; ok {
+		return inventory
+	}
+
+	return model.Inventory{ID: skuID, InventoryType: model.InventoryTypeInfinite}
+}
+
+func (s *Server) getInventory(r *http.Request, params map[string]string, body []byte) response {
+	if sku, _ := s.findSKU(params["collection"], params["item"]); sku == nil {
+		return notFound(r)
+	}
+
+	return ok(s.inventory(params["item"]))
+}
+
+func (s *Server) updateInventory(r *http.Request, params map[string]string, body []byte) response {
+	sku, siteID := s.findSKU(params["collection"], params["item"])
+	if sku == nil {
+		return notFound(r)
+	}
+
+	var req struct {
+		Fields model.UpdateInventoryRequest `json:"fields"`
+	}
+	if res := decodeBody(r, body, &req); res != nil {
+		return *res
+	}
+
+	fields := req.Fields
+	switch {
+	case fields.InventoryType != model.InventoryTypeFinite && fields.InventoryType != model.InventoryTypeInfinite:
+		return validationError(r, "Field 'inventoryType': Must be one of finite, infinite")
+	case fields.Quantity != nil && fields.UpdateQuantity != nil:
+		return validationError(r, "Field 'quantity': Cannot be set together with updateQuantity")
+	}
+
+	inventory := s.inventory(sku.ID)
+	if fields.InventoryType == model.InventoryTypeInfinite {
+		inventory.Quantity = 0
+	} else {
+		if inventory.InventoryType == model.InventoryTypeInfinite {
+			inventory.Quantity = 0
+		}
+		if fields.Quantity != nil {
+			inventory.Quantity = *fields.Quantity
+		}
+		if fields.UpdateQuantity != nil {
+			inventory.Quantity += *fields.UpdateQuantity
+		}
+	}
+	inventory.InventoryType = fields.InventoryType
+	s.data.Inventory[sku.ID] = inventory
+
+	res := ok(inventory)
+	res.deliveries = s.trigger(siteID, model.TriggerEcommInventoryChanged, model.InventoryChanged{
+		ID:            inventory.ID,
+		Quantity:      inventory.Quantity,
+		InventoryType: inventory.InventoryType,
+	})
+	return res
+}
+
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
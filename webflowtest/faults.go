@@ -0,0 +1,155 @@
+package webflowtest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fault scripts a failure of the requests matching Method and Route. Faults
+// count the requests they match, so a scenario such as "the third call is
+// rate limited" is deterministic.
+type Fault struct {
+	// Method matches every method when empty.
+	Method string
+	// Route is a route pattern such as "/collections/:collection/items" or a
+	// literal path. It matches every route when empty.
+	Route string
+	// After lets that many matching requests through before the fault
+	// applies.
+	After int
+	// Times is how often the fault applies, every time when zero.
+	Times int
+
+	// Latency delays the response, or the failure when one is set.
+	Latency time.Duration
+	// Status answers with this status and an API error body instead of
+	// calling the route.
+	Status int
+	// RetryAfter is sent as the Retry-After header, in whole seconds.
+	RetryAfter time.Duration
+	// Problems are the validation problems of a 400 answer.
+	Problems []string
+	// Drop closes the connection without answering.
+	Drop bool
+
+	segments []string
+	seen     int
+	applied  int
+}
+
+// RateLimit answers every request after the first after ones with 429 and a
+// Retry-After header, like the API does once the per minute limit is hit.
+func RateLimit(after int, retryAfter time.Duration) Fault {
+	return Fault{After: after, Status: http.StatusTooManyRequests, RetryAfter: retryAfter}
+}
+
+// Latency delays every request to a route.
+func Latency(method string, route string, latency time.Duration) Fault {
+	return Fault{Method: method, Route: route, Latency: latency}
+}
+
+// FailRoute answers every request to a route with status.
+func FailRoute(method string, route string, status int) Fault {
+	return Fault{Method: method, Route: route, Status: status}
+}
+
+// ValidationFailure answers every request to a route with a 400 validation
+// error listing problems.
+func ValidationFailure(method string, route string, problems ...string) Fault {
+	return Fault{Method: method, Route: route, Status: http.StatusBadRequest, Problems: problems}
+}
+
+// DropConnection closes the connection of every request to a route.
+func DropConnection(method string, route string) Fault {
+	return Fault{Method: method, Route: route, Drop: true}
+}
+
+// Inject adds a fault. Faults are checked in the order they were added;
+// latencies add up and the first failure wins.
+func (s *Server) Inject(faults ...Fault) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range faults {
+		f := faults[i]
+		if f.Route != "" {
+			f.segments = strings.Split(strings.Trim(f.Route, "/"), "/")
+		}
+		s.faults = append(s.faults, &f)
+	}
+
+	return s
+}
+
+// ClearFaults removes every fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+func (f *Fault) matches(r *http.Request, segments []string) bool {
+	if f.Method != "" && f.Method != r.Method {
+		return false
+	}
+	if f.segments == nil {
+		return true
+	}
+
+	_, ok := route{segments: f.segments}.match(segments)
+	return ok
+}
+
+// injected is the combined effect of the faults applying to a request.
+type injected struct {
+	latency time.Duration
+	fault   *Fault
+}
+
+func (s *Server) faultsFor(r *http.Request) injected {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var in injected
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	for _, f := range s.faults {
+		if !f.matches(r, segments) {
+			continue
+		}
+
+		f.seen++
+		if f.seen <= f.After || (f.Times > 0 && f.applied >= f.Times) {
+			continue
+		}
+		f.applied++
+
+		in.latency += f.Latency
+		if in.fault == nil && (f.Status != 0 || f.Drop) {
+			in.fault = f
+		}
+	}
+
+	return in
+}
+
+func (f *Fault) response(r *http.Request) response {
+	var res response
+	switch f.Status {
+	case http.StatusTooManyRequests:
+		res = errorResponse(r, f.Status, "RateLimit", "Rate limit hit")
+	case http.StatusBadRequest:
+		res = validationError(r, f.Problems...)
+	default:
+		res = errorResponse(r, f.Status, strings.ReplaceAll(http.StatusText(f.Status), " ", ""), http.StatusText(f.Status))
+	}
+
+	if f.RetryAfter > 0 {
+		res.header = http.Header{}
+		res.header.Set("Retry-After", strconv.Itoa(int(f.RetryAfter/time.Second)))
+	}
+
+	return res
+}
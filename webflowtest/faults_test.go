@@ -0,0 +1,116 @@
+package webflowtest_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/nasrul21/go-webflow/product"
+	"github.com/nasrul21/go-webflow/webflowtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit(t *testing.T) {
+	srv := newServer(t)
+	srv.Inject(webflowtest.RateLimit(2, 30*time.Second))
+	wf := srv.Webflow()
+
+	for i := 0; i < 2; i++ {
+		_, err := wf.Site.GetList()
+		assert.Nil(t, err)
+	}
+
+	_, err := wf.Site.GetList()
+	assert.True(t, common.IsRateLimited(err))
+	assert.Equal(t, "RateLimit", err.Name)
+
+	resp, _ := srv.Client().Get(srv.URL + "/sites")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "30", resp.Header.Get("Retry-After"))
+	resp.Body.Close()
+
+	srv.ClearFaults()
+	_, err = wf.Site.GetList()
+	assert.Nil(t, err)
+}
+
+func TestRetryAfterRateLimit(t *testing.T) {
+	srv := newServer(t)
+	wf := srv.Webflow()
+
+	price := model.NewMoney(2000, "USD")
+	created, err := wf.Product.Create(siteID, model.CreateProductRequest{
+		Product: model.ProductFields{Name: "T-Shirt", Slug: "t-shirt"},
+		SKU:     model.SKUFields{Price: &price},
+	})
+	assert.Nil(t, err)
+
+	srv.Inject(webflowtest.Fault{
+		Method: http.MethodPost,
+		Route:  "/sites/:site/products/:product/skus",
+		Times:  2,
+		Status: http.StatusTooManyRequests,
+	})
+
+	variants := product.NewVariantMatrix("T-Shirt", price).Option("Size", "S", "M").Variants()
+	skus, err := product.SubmitVariants(context.Background(), wf.Product, siteID, created.Product.ID, variants, product.SubmitOptions{
+		RetryWait: time.Millisecond,
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, skus, 2)
+
+	calls := 0
+	for _, req := range srv.Requests() {
+		if req.Method == http.MethodPost && req.Path == fmt.Sprintf("/sites/%s/products/%s/skus", siteID, created.Product.ID) {
+			calls++
+		}
+	}
+	assert.Equal(t, 3, calls)
+}
+
+func TestRouteFaults(t *testing.T) {
+	srv := newServer(t)
+	srv.Inject(
+		webflowtest.FailRoute(http.MethodGet, "/sites/:site/domains", http.StatusInternalServerError),
+		webflowtest.ValidationFailure(http.MethodPatch, "/sites/:site/order/:order", "Field 'comment': Too long"),
+		webflowtest.DropConnection("", "/user"),
+	)
+	wf := srv.Webflow()
+
+	_, err := wf.Domain.GetList(siteID)
+	assert.Equal(t, http.StatusInternalServerError, err.Code)
+	assert.Equal(t, "InternalServerError", err.Name)
+
+	_, err = wf.Order.Update(siteID, "dd6-5ca", model.UpdateOrderRequest{Comment: "Gift"})
+	assert.Equal(t, http.StatusBadRequest, err.Code)
+	assert.Equal(t, []interface{}{"Field 'comment': Too long"}, err.Problems)
+
+	_, err = wf.Meta.GetUser()
+	assert.Equal(t, common.GoErrCode, err.Err)
+
+	sites, err := wf.Site.GetList()
+	assert.Nil(t, err)
+	assert.Len(t, sites, 1)
+}
+
+func TestLatency(t *testing.T) {
+	srv := newServer(t)
+	srv.Inject(webflowtest.Latency(http.MethodGet, "/sites", 200*time.Millisecond))
+	wf := srv.Webflow()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := wf.Site.GetListWithContext(ctx)
+	assert.NotNil(t, err)
+
+	start := time.Now()
+	_, err = wf.Domain.GetList(siteID)
+	assert.Nil(t, err)
+	assert.Less(t, time.Since(start), 200*time.Millisecond)
+}
@@ -0,0 +1,101 @@
+package webflowtest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/nasrul21/go-webflow/model"
+)
+
+// Fixtures is the state of the fake API. Maps are keyed by the ID of the
+// parent resource: domains, collections, products, orders and webhooks by
+// site ID, items by collection ID.
+type Fixtures struct {
+	Info        *model.AuthorizationInfo           `json:"info,omitempty"`
+	User        *model.AuthorizedUser              `json:"user,omitempty"`
+	Sites       []model.Site                       `json:"sites,omitempty"`
+	Domains     map[string][]model.Domain          `json:"domains,omitempty"`
+	Collections map[string][]model.Collection      `json:"collections,omitempty"`
+	Items       map[string][]model.Item            `json:"items,omitempty"`
+	Products    map[string][]model.ProductWithSKUs `json:"products,omitempty"`
+	Orders      map[string][]model.Order           `json:"orders,omitempty"`
+	Webhooks    map[string][]model.Webhook         `json:"webhooks,omitempty"`
+	// Inventory is keyed by SKU ID. SKUs without an entry have infinite
+	// inventory.
+	Inventory map[string]model.Inventory `json:"inventory,omitempty"`
+}
+
+// LoadFixtures reads fixtures from a JSON file.
+func LoadFixtures(path string) (*Fixtures, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var fixtures Fixtures
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, err
+	}
+
+	return &fixtures, nil
+}
+
+// clone deep copies the fixtures through JSON, so the server never shares
+// state with the caller.
+func (f *Fixtures) clone() *Fixtures {
+	data, err := json.Marshal(f)
+	if err != nil {
+		panic("webflowtest: fixtures cannot be copied: " + err.Error())
+	}
+
+	var c Fixtures
+	if err := json.Unmarshal(data, &c); err != nil {
+		panic("webflowtest: fixtures cannot be copied: " + err.Error())
+	}
+	if c.Domains == nil {
+		c.Domains = map[string][]model.Domain{}
+	}
+	if c.Collections == nil {
+		c.Collections = map[string][]model.Collection{}
+	}
+	if c.Items == nil {
+		c.Items = map[string][]model.Item{}
+	}
+	if c.Products == nil {
+		c.Products = map[string][]model.ProductWithSKUs{}
+	}
+	if c.Orders == nil {
+		c.Orders = map[string][]model.Order{}
+	}
+	if c.Webhooks == nil {
+		c.Webhooks = map[string][]model.Webhook{}
+	}
+	if c.Inventory == nil {
+		c.Inventory = map[string]model.Inventory{}
+	}
+
+	return &c
+}
+
+func (f *Fixtures) site(siteID string) *model.Site {
+	for i := range f.Sites {
+		if f.Sites[i].ID == siteID {
+			return &f.Sites[i]
+		}
+	}
+
+	return nil
+}
+
+// collection returns a collection and the ID of its site.
+func (f *Fixtures) collection(collectionID string) (*model.Collection, string) {
+	for siteID, collections := range f.Collections {
+		for i := range collections {
+			if collections[i].ID == collectionID {
+				return &collections[i], siteID
+			}
+		}
+	}
+
+	return nil, ""
+}
@@ -0,0 +1,182 @@
+package webflowtest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/nasrul21/go-webflow/model"
+)
+
+const maxPageSize = 100
+
+func (s *Server) registerRoutes() {
+	s.handle(http.MethodGet, "/info", s.getInfo)
+	s.handle(http.MethodGet, "/user", s.getUser)
+	s.handle(http.MethodGet, "/sites", s.listSites)
+	s.handle(http.MethodGet, "/sites/:site", s.getSite)
+	s.handle(http.MethodGet, "/sites/:site/domains", s.listDomains)
+	s.handle(http.MethodPost, "/sites/:site/publish", s.publishSite)
+
+	s.handle(http.MethodGet, "/sites/:site/collections", s.listCollections)
+	s.handle(http.MethodGet, "/collections/:collection", s.getCollection)
+	s.handle(http.MethodGet, "/collections/:collection/items", s.listItems)
+	s.handle(http.MethodPost, "/collections/:collection/items", s.createItem)
+	s.handle(http.MethodGet, "/collections/:collection/items/:item", s.getItem)
+	s.handle(http.MethodPut, "/collections/:collection/items/:item", s.replaceItem)
+	s.handle(http.MethodPatch, "/collections/:collection/items/:item", s.patchItem)
+	s.handle(http.MethodDelete, "/collections/:collection/items/:item", s.deleteItem)
+
+	s.handle(http.MethodGet, "/sites/:site/products", s.listProducts)
+	s.handle(http.MethodPost, "/sites/:site/products", s.createProduct)
+	s.handle(http.MethodGet, "/sites/:site/products/:product", s.getProduct)
+	s.handle(http.MethodPatch, "/sites/:site/products/:product", s.updateProduct)
+	s.handle(http.MethodPost, "/sites/:site/products/:product/skus", s.createSKUs)
+	s.handle(http.MethodPatch, "/sites/:site/products/:product/skus/:sku", s.updateSKU)
+	s.handle(http.MethodGet, "/collections/:collection/items/:item/inventory", s.getInventory)
+	s.handle(http.MethodPatch, "/collections/:collection/items/:item/inventory", s.updateInventory)
+
+	s.handle(http.MethodGet, "/sites/:site/orders", s.listOrders)
+	s.handle(http.MethodGet, "/sites/:site/order/:order", s.getOrder)
+	s.handle(http.MethodPatch, "/sites/:site/order/:order", s.updateOrder)
+	s.handle(http.MethodPost, "/sites/:site/order/:order/fulfill", s.fulfillOrder)
+	s.handle(http.MethodPost, "/sites/:site/order/:order/unfulfill", s.unfulfillOrder)
+	s.handle(http.MethodPost, "/sites/:site/order/:order/refund", s.refundOrder)
+
+	s.handle(http.MethodGet, "/sites/:site/webhooks", s.listWebhooks)
+	s.handle(http.MethodPost, "/sites/:site/webhooks", s.createWebhook)
+	s.handle(http.MethodGet, "/sites/:site/webhooks/:webhook", s.getWebhook)
+	s.handle(http.MethodDelete, "/sites/:site/webhooks/:webhook", s.deleteWebhook)
+}
+
+// pagination reads offset and limit with the defaults and bounds of the
+// real API.
+func pagination(r *http.Request) (int, int, []string) {
+	offset, limit := 0, maxPageSize
+	var problems []string
+
+	query := r.URL.Query()
+	if v := query.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			problems = append(problems, "Field 'offset': Must be a positive integer")
+		}
+		offset = n
+	}
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxPageSize {
+			problems = append(problems, fmt.Sprintf("Field 'limit': Must be between 1 and %d", maxPageSize))
+		}
+		limit = n
+	}
+
+	return offset, limit, problems
+}
+
+// bounds returns the slice bounds of a page of a list of n elements.
+func bounds(offset int, limit int, n int) (int, int) {
+	if offset > n {
+		offset = n
+	}
+	end := offset + limit
+	if end > n {
+		end = n
+	}
+
+	return offset, end
+}
+
+func (s *Server) getInfo(r *http.Request, params map[string]string, body []byte) response {
+	if s.data.Info != nil {
+		return ok(s.data.Info)
+	}
+
+	info := model.AuthorizationInfo{
+		ID:        "webflowtest",
+		CreatedOn: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		GrantType: "authorization_code",
+		LastUsed:  *s.now(),
+		RateLimit: 60,
+		Status:    "confirmed",
+	}
+	for _, site := range s.data.Sites {
+		info.Sites = append(info.Sites, site.ID)
+	}
+
+	return ok(info)
+}
+
+func (s *Server) getUser(r *http.Request, params map[string]string, body []byte) response {
+	if s.data.User == nil {
+		return notFound(r)
+	}
+
+	return ok(s.data.User)
+}
+
+func (s *Server) listSites(r *http.Request, params map[string]string, body []byte) response {
+	return ok(append([]model.Site{}, s.data.Sites...))
+}
+
+func (s *Server) getSite(r *http.Request, params map[string]string, body []byte) response {
+	site := s.data.site(params["site"])
+	if site == nil {
+		return notFound(r)
+	}
+
+	return ok(site)
+}
+
+func (s *Server) listDomains(r *http.Request, params map[string]string, body []byte) response {
+	if s.data.site(params["site"]) == nil {
+		return notFound(r)
+	}
+
+	return ok(append([]model.Domain{}, s.data.Domains[params["site"]]...))
+}
+
+// publishSite accepts the custom domains of a site and its webflow.io
+// subdomain, and publishes at once instead of queueing.
+func (s *Server) publishSite(r *http.Request, params map[string]string, body []byte) response {
+	site := s.data.site(params["site"])
+	if site == nil {
+		return notFound(r)
+	}
+
+	var req model.PublishSiteRequest
+	if res := decodeBody(r, body, &req); res != nil {
+		return *res
+	}
+
+	known := map[string]bool{site.ShortName + ".webflow.io": true}
+	for _, domain := range s.data.Domains[site.ID] {
+		known[domain.Name] = true
+	}
+
+	var problems []string
+	if len(req.Domains) == 0 {
+		problems = append(problems, "Field 'domains': Field is required")
+	}
+	for _, domain := range req.Domains {
+		if !known[domain] {
+			problems = append(problems, fmt.Sprintf("Field 'domains': Unknown domain '%s'", domain))
+		}
+	}
+	if len(problems) > 0 {
+		return validationError(r, problems...)
+	}
+
+	site.LastPublished = *s.now()
+
+	res := ok(model.PublishSiteResponse{Queued: true})
+	res.deliveries = s.trigger(site.ID, model.TriggerSitePublish, model.SitePublish{
+		Site:        site.ID,
+		PublishTime: site.LastPublished.UnixNano() / int64(time.Millisecond),
+		Domains:     req.Domains,
+		PublishedBy: model.SitePublishAuthor{Name: "webflowtest"},
+	})
+
+	return res
+}
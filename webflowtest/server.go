@@ -0,0 +1,327 @@
+// Package webflowtest provides an in-memory fake of the Webflow API for
+// integration tests. The server keeps its state in a Fixtures value, answers
+// with the status codes and error bodies of the real API and delivers
+// webhooks to the URLs registered on it. Faults such as rate limits,
+// latency and dropped connections can be scripted with Inject.
+//
+//	srv := webflowtest.NewServer(fixtures)
+//	defer srv.Close()
+//	wf := srv.Webflow()
+package webflowtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nasrul21/go-webflow"
+	"github.com/nasrul21/go-webflow/client"
+	"github.com/nasrul21/go-webflow/common"
+)
+
+const DefaultToken = "webflowtest-token"
+
+// RecordedRequest is a request received by the server.
+type RecordedRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Body   []byte
+}
+
+type Server struct {
+	*httptest.Server
+	// Token is the bearer token requests must carry. Any token is accepted
+	// when it is empty.
+	Token string
+	// Now returns the time used for created and updated timestamps.
+	Now func() time.Time
+
+	mu       sync.Mutex
+	data     *Fixtures
+	routes   []route
+	faults   []*Fault
+	requests []RecordedRequest
+	nextID   int
+}
+
+// NewServer starts a server seeded with a copy of fixtures, which may be
+// nil. Close it when done.
+func NewServer(fixtures *Fixtures) *Server {
+	s := NewUnstartedServer(fixtures)
+	s.Start()
+	return s
+}
+
+// NewUnstartedServer returns a server that is not listening yet, so its
+// fields can be changed before calling Start.
+func NewUnstartedServer(fixtures *Fixtures) *Server {
+	s := &Server{
+		Token: DefaultToken,
+		Now:   time.Now,
+	}
+	s.Seed(fixtures)
+	s.registerRoutes()
+	s.Server = httptest.NewUnstartedServer(s)
+
+	return s
+}
+
+// Seed replaces the state of the server with a copy of fixtures.
+func (s *Server) Seed(fixtures *Fixtures) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if fixtures == nil {
+		fixtures = &Fixtures{}
+	}
+	s.data = fixtures.clone()
+}
+
+// Snapshot returns a copy of the current state of the server.
+func (s *Server) Snapshot() *Fixtures {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.data.clone()
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []RecordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]RecordedRequest(nil), s.requests...)
+}
+
+// Option returns client options pointing at the server.
+func (s *Server) Option() common.Option {
+	return common.Option{
+		ApiKey:  s.token(),
+		BaseURL: s.URL,
+	}
+}
+
+// Webflow returns a client pointing at the server.
+func (s *Server) Webflow() *webflow.Webflow {
+	wf := webflow.New(s.token()).WithHttpClient(&client.ClientImpl{HttpClient: s.Client()})
+	wf.Opt.BaseURL = s.URL
+
+	return wf
+}
+
+func (s *Server) token() string {
+	if s.Token == "" {
+		return DefaultToken
+	}
+
+	return s.Token
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	auth := r.Header.Get("Authorization")
+	if s.Token == "" {
+		return strings.HasPrefix(auth, "Bearer ")
+	}
+
+	return auth == "Bearer "+s.Token
+}
+
+func (s *Server) id() string {
+	s.nextID++
+	return fmt.Sprintf("%024x", s.nextID)
+}
+
+func (s *Server) now() *time.Time {
+	now := s.Now().UTC()
+	return &now
+}
+
+// response is what a handler produces; deliveries are sent once the state
+// lock is released so a webhook receiver may call back into the server.
+type response struct {
+	status     int
+	header     http.Header
+	body       interface{}
+	deliveries []delivery
+}
+
+type handlerFunc func(r *http.Request, params map[string]string, body []byte) response
+
+type route struct {
+	method   string
+	segments []string
+	handler  handlerFunc
+}
+
+func (s *Server) handle(method string, pattern string, handler handlerFunc) {
+	s.routes = append(s.routes, route{
+		method:   method,
+		segments: strings.Split(strings.Trim(pattern, "/"), "/"),
+		handler:  handler,
+	})
+}
+
+func (rt route) match(segments []string) (map[string]string, bool) {
+	if len(segments) != len(rt.segments) {
+		return nil, false
+	}
+
+	params := map[string]string{}
+	for i, segment := range rt.segments {
+		if strings.HasPrefix(segment, ":") {
+			params[segment[1:]] = segments[i]
+		} else if segment != segments[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	s.record(r, body)
+
+	in := s.faultsFor(r)
+	if in.latency > 0 {
+		timer := time.NewTimer(in.latency)
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			timer.Stop()
+			return
+		}
+	}
+
+	var res response
+	switch {
+	case in.fault != nil && in.fault.Drop:
+		if hijacker, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hijacker.Hijack(); err == nil {
+				conn.Close()
+				return
+			}
+		}
+		panic(http.ErrAbortHandler)
+	case in.fault != nil:
+		res = in.fault.response(r)
+	default:
+		res = s.serve(r, body)
+	}
+
+	for key, values := range res.header {
+		w.Header()[key] = values
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(res.status)
+	if res.body != nil {
+		_ = json.NewEncoder(w).Encode(res.body)
+	}
+
+	for _, d := range res.deliveries {
+		s.deliver(d)
+	}
+}
+
+func (s *Server) record(r *http.Request, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, RecordedRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.RawQuery,
+		Body:   body,
+	})
+}
+
+func (s *Server) serve(r *http.Request, body []byte) response {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.authorized(r) {
+		return errorResponse(r, http.StatusUnauthorized, "NotAuthorized", "Not Authorized")
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	methodAllowed := false
+	for _, rt := range s.routes {
+		params, ok := rt.match(segments)
+		if !ok {
+			continue
+		}
+		if rt.method != r.Method {
+			methodAllowed = true
+			continue
+		}
+
+		return rt.handler(r, params, body)
+	}
+
+	if methodAllowed {
+		return errorResponse(r, http.StatusMethodNotAllowed, "MethodNotAllowed", "Method not allowed")
+	}
+
+	return errorResponse(r, http.StatusNotFound, "RouteNotFoundError", fmt.Sprintf("Route not found: %s %s", r.Method, r.URL.Path))
+}
+
+func ok(body interface{}) response {
+	return response{status: http.StatusOK, body: body}
+}
+
+func errorResponse(r *http.Request, status int, name string, msg string, problems ...string) response {
+	e := common.Error{
+		Code:    status,
+		Message: msg,
+		Name:    name,
+		Path:    r.URL.Path,
+		Err:     fmt.Sprintf("%s: %s", name, msg),
+	}
+	if len(problems) > 0 {
+		e.Problems = problems
+	}
+
+	return response{status: status, body: e}
+}
+
+func notFound(r *http.Request) response {
+	return errorResponse(r, http.StatusNotFound, "NotFound", "Requested resource not found")
+}
+
+func validationError(r *http.Request, problems ...string) response {
+	return errorResponse(r, http.StatusBadRequest, "ValidationError", "Validation Failure", problems...)
+}
+
+func decodeBody(r *http.Request, body []byte, v interface{}) *response {
+	if err := json.Unmarshal(body, v); err != nil {
+		res := errorResponse(r, http.StatusBadRequest, "SyntaxError", fmt.Sprintf("Invalid JSON body: %s", err))
+		return &res
+	}
+
+	return nil
+}
+
+// deliver posts a webhook event to a registered URL in the envelope the
+// webhook package reads. Delivery is best effort, like the real API.
+func (s *Server) deliver(d delivery) {
+	body, err := json.Marshal(map[string]interface{}{
+		"triggerType": d.triggerType,
+		"payload":     d.payload,
+	})
+	if err != nil {
+		return
+	}
+
+	resp, err := http.Post(d.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,252 @@
+package webflowtest_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nasrul21/go-webflow/client"
+	"github.com/nasrul21/go-webflow/common"
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/nasrul21/go-webflow/webflowtest"
+	"github.com/nasrul21/go-webflow/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	siteID       = "580e63e98c9a982ac9b8b741"
+	collectionID = "580e63fc8c9a982ac9b8b745"
+)
+
+func newServer(t *testing.T) *webflowtest.Server {
+	fixtures, err := webflowtest.LoadFixtures("testdata/fixtures.json")
+	assert.Nil(t, err)
+
+	srv := webflowtest.NewServer(fixtures)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// call sends a raw request for endpoints the library has no service for.
+func call(srv *webflowtest.Server, method string, path string, body interface{}, result interface{}) *common.Error {
+	opt := srv.Option()
+	c := &client.ClientImpl{HttpClient: srv.Client()}
+
+	return c.Call(context.Background(), method, opt.BaseURL+path, opt.ApiKey, nil, body, result)
+}
+
+func TestMetaAndSites(t *testing.T) {
+	srv := newServer(t)
+	wf := srv.Webflow()
+
+	info, err := wf.Meta.GetInfo()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{siteID}, info.Sites)
+
+	user, err := wf.Meta.GetUser()
+	assert.Nil(t, err)
+	assert.Equal(t, "some@email.com", user.User.Email)
+
+	sites, err := wf.Site.GetList()
+	assert.Nil(t, err)
+	assert.Len(t, sites, 1)
+	assert.Equal(t, "api_docs_sample_json", sites[0].Name)
+
+	domains, err := wf.Domain.GetList(siteID)
+	assert.Nil(t, err)
+	assert.Equal(t, []model.Domain{{ID: "589a331aa51e760df7ccb89d", Name: "test-api-domain.com"}}, domains)
+
+	published, err := wf.Site.Publish(siteID, model.PublishSiteRequest{Domains: []string{"test-api-domain.com"}})
+	assert.Nil(t, err)
+	assert.True(t, published.Queued)
+
+	_, err = wf.Site.Publish(siteID, model.PublishSiteRequest{Domains: []string{"example.com"}})
+	assert.Equal(t, http.StatusBadRequest, err.Code)
+	assert.Equal(t, []interface{}{"Field 'domains': Unknown domain 'example.com'"}, err.Problems)
+
+	_, err = wf.Domain.GetList("unknown")
+	assert.Equal(t, http.StatusNotFound, err.Code)
+	assert.Equal(t, "NotFound", err.Name)
+}
+
+func TestUnauthorized(t *testing.T) {
+	srv := newServer(t)
+	wf := srv.Webflow()
+	wf.Opt.ApiKey = "wrong"
+
+	_, err := wf.Site.GetList()
+	assert.Equal(t, http.StatusUnauthorized, err.Code)
+	assert.Equal(t, "Not Authorized", err.Message)
+}
+
+func TestItems(t *testing.T) {
+	srv := newServer(t)
+
+	var list model.ItemList
+	err := call(srv, http.MethodGet, fmt.Sprintf("/collections/%s/items", collectionID), nil, &list)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, list.Total)
+	assert.Equal(t, "Arthur Dent", list.Items[0].Fields["author"])
+	assert.Equal(t, float64(3), list.Items[0].Fields["reading-time"])
+
+	var created model.Item
+	err = call(srv, http.MethodPost, fmt.Sprintf("/collections/%s/items", collectionID), map[string]interface{}{
+		"fields": map[string]interface{}{"name": "Second", "slug": "second", "author": "Ford Prefect", "_archived": false, "_draft": false},
+	}, &created)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, created.ID)
+	assert.Equal(t, collectionID, created.CollectionID)
+	assert.NotNil(t, created.CreatedOn)
+
+	err = call(srv, http.MethodPost, fmt.Sprintf("/collections/%s/items", collectionID), map[string]interface{}{
+		"fields": map[string]interface{}{"name": "Third", "slug": "second", "reading-time": "long", "colour": "red"},
+	}, &created)
+	assert.Equal(t, http.StatusBadRequest, err.Code)
+	assert.Equal(t, "ValidationError", err.Name)
+	assert.Equal(t, []interface{}{
+		"Field 'author': Field is required",
+		"Field 'colour': Field not described in schema",
+		"Field 'reading-time': Expected a number",
+		"Field 'slug': Unique value is already in database: 'second'",
+	}, err.Problems)
+
+	var patched model.Item
+	err = call(srv, http.MethodPatch, fmt.Sprintf("/collections/%s/items/%s", collectionID, created.ID), map[string]interface{}{
+		"fields": map[string]interface{}{"featured": true},
+	}, &patched)
+	assert.Nil(t, err)
+	assert.Equal(t, "Ford Prefect", patched.Fields["author"])
+	assert.Equal(t, true, patched.Fields["featured"])
+
+	var deleted map[string]int
+	err = call(srv, http.MethodDelete, fmt.Sprintf("/collections/%s/items/%s", collectionID, created.ID), nil, &deleted)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, deleted["deleted"])
+	assert.Len(t, srv.Snapshot().Items[collectionID], 1)
+
+	err = call(srv, http.MethodGet, fmt.Sprintf("/collections/%s/items?limit=500", collectionID), nil, &list)
+	assert.Equal(t, http.StatusBadRequest, err.Code)
+}
+
+func TestProducts(t *testing.T) {
+	srv := newServer(t)
+	wf := srv.Webflow()
+
+	price := model.NewMoney(2000, "USD")
+	created, err := wf.Product.Create(siteID, model.CreateProductRequest{
+		Product: model.ProductFields{Name: "T-Shirt", Slug: "t-shirt"},
+		SKU:     model.SKUFields{Name: "T-Shirt S", Slug: "t-shirt-s", Price: &price},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, created.SKU.ID, created.Product.DefaultSKU)
+
+	_, err = wf.Product.Create(siteID, model.CreateProductRequest{
+		Product: model.ProductFields{Name: "T-Shirt", Slug: "t-shirt"},
+	})
+	assert.Equal(t, http.StatusBadRequest, err.Code)
+	assert.Equal(t, []interface{}{
+		"Field 'product.slug': Unique value is already in database: 't-shirt'",
+		"Field 'sku.price': Field is required",
+	}, err.Problems)
+
+	skus, err := wf.Product.CreateSKUs(siteID, created.Product.ID, model.CreateSKUsRequest{
+		SKUs: []model.SKUFields{{Name: "T-Shirt M", Slug: "t-shirt-m", Price: &price}},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, skus, 1)
+
+	updated, err := wf.Product.UpdateSKU(siteID, created.Product.ID, skus[0].ID, model.UpdateSKURequest{SKU: model.SKUFields{SKU: "TS-M"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "TS-M", updated.SKU)
+	assert.Equal(t, price, updated.Price)
+
+	list, err := wf.Product.GetList(siteID, model.PaginationParams{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, list.Total)
+	assert.Len(t, list.Items[0].SKUs, 2)
+	assert.Equal(t, created.SKU.CollectionID, skus[0].CollectionID)
+
+	inventory, err := wf.Inventory.Get(skus[0].CollectionID, skus[0].ID)
+	assert.Nil(t, err)
+	assert.Equal(t, model.InventoryTypeInfinite, inventory.InventoryType)
+
+	_, err = wf.Inventory.Update(skus[0].CollectionID, skus[0].ID, model.SetInventoryQuantity(5))
+	assert.Nil(t, err)
+	inventory, err = wf.Inventory.Update(skus[0].CollectionID, skus[0].ID, model.AdjustInventoryQuantity(-2))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, inventory.Quantity)
+
+	_, err = wf.Inventory.Get(collectionID, skus[0].ID)
+	assert.Equal(t, http.StatusNotFound, err.Code)
+}
+
+func TestOrders(t *testing.T) {
+	srv := newServer(t)
+	wf := srv.Webflow()
+
+	orders, err := wf.Order.GetList(siteID, model.OrderListParams{Status: model.OrderStatusRefunded})
+	assert.Nil(t, err)
+	assert.Len(t, orders, 1)
+	assert.Equal(t, "a41-7bc", orders[0].OrderID)
+
+	fulfilled, err := wf.Order.Fulfill(siteID, "dd6-5ca", model.FulfillOrderRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, model.OrderStatusFulfilled, fulfilled.Status)
+	assert.NotNil(t, fulfilled.FulfilledOn)
+
+	_, err = wf.Order.WithoutTransitionValidation().Fulfill(siteID, "dd6-5ca", model.FulfillOrderRequest{})
+	assert.Equal(t, http.StatusConflict, err.Code)
+
+	updated, err := wf.Order.Update(siteID, "dd6-5ca", model.UpdateOrderRequest{ShippingTracking: "RM123"})
+	assert.Nil(t, err)
+	assert.Equal(t, "RM123", updated.ShippingTracking)
+
+	_, err = wf.Order.Get(siteID, "unknown")
+	assert.Equal(t, http.StatusNotFound, err.Code)
+}
+
+func TestWebhooks(t *testing.T) {
+	srv := newServer(t)
+
+	store := webhook.NewMemoryStore()
+	dispatcher := webhook.NewDispatcher()
+	receiver := httptest.NewServer(webhook.NewReceiver(dispatcher).WithStore(store))
+	defer receiver.Close()
+
+	var registered model.Webhook
+	err := call(srv, http.MethodPost, fmt.Sprintf("/sites/%s/webhooks", siteID), map[string]string{
+		"triggerType": model.TriggerCollectionItemCreated,
+		"url":         receiver.URL,
+	}, &registered)
+	assert.Nil(t, err)
+	assert.Equal(t, siteID, registered.Site)
+
+	err = call(srv, http.MethodPost, fmt.Sprintf("/sites/%s/webhooks", siteID), map[string]string{
+		"triggerType": "item_moved",
+		"url":         "not a url",
+	}, &registered)
+	assert.Equal(t, http.StatusBadRequest, err.Code)
+
+	var item model.Item
+	err = call(srv, http.MethodPost, fmt.Sprintf("/collections/%s/items", collectionID), map[string]interface{}{
+		"fields": map[string]interface{}{"name": "Second", "slug": "second", "author": "Ford Prefect"},
+	}, &item)
+	assert.Nil(t, err)
+
+	events, _ := store.Events()
+	assert.Len(t, events, 1)
+	assert.Equal(t, model.TriggerCollectionItemCreated, events[0].TriggerType)
+
+	srv.Emit(siteID, model.TriggerFormSubmission, model.FormSubmission{Name: "Contact", Site: siteID})
+	events, _ = store.Events()
+	assert.Len(t, events, 1)
+
+	var webhooks []model.Webhook
+	err = call(srv, http.MethodGet, fmt.Sprintf("/sites/%s/webhooks", siteID), nil, &webhooks)
+	assert.Nil(t, err)
+	assert.Len(t, webhooks, 1)
+	assert.NotNil(t, webhooks[0].LastUsed)
+}
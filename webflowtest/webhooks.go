@@ -0,0 +1,139 @@
+package webflowtest
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/nasrul21/go-webflow/model"
+)
+
+var triggerTypes = map[string]bool{
+	model.TriggerFormSubmission:        true,
+	model.TriggerSitePublish:           true,
+	model.TriggerEcommNewOrder:         true,
+	model.TriggerEcommOrderChanged:     true,
+	model.TriggerEcommInventoryChanged: true,
+	model.TriggerCollectionItemCreated: true,
+	model.TriggerCollectionItemChanged: true,
+	model.TriggerCollectionItemDeleted: true,
+}
+
+type delivery struct {
+	url         string
+	triggerType string
+	payload     interface{}
+}
+
+// trigger returns the deliveries of an event to the webhooks of a site.
+func (s *Server) trigger(siteID string, triggerType string, payload interface{}) []delivery {
+	var deliveries []delivery
+	webhooks := s.data.Webhooks[siteID]
+	for i := range webhooks {
+		if webhooks[i].TriggerType != triggerType {
+			continue
+		}
+
+		webhooks[i].LastUsed = s.now()
+		deliveries = append(deliveries, delivery{
+			url:         webhooks[i].URL,
+			triggerType: triggerType,
+			payload:     payload,
+		})
+	}
+
+	return deliveries
+}
+
+func (s *Server) findWebhook(siteID string, webhookID string) int {
+	for i, webhook := range s.data.Webhooks[siteID] {
+		if webhook.ID == webhookID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (s *Server) listWebhooks(r *http.Request, params map[string]string, body []byte) response {
+	if s.data.site(params["site"]) == nil {
+		return notFound(r)
+	}
+
+	return ok(append([]model.Webhook{}, s.data.Webhooks[params["site"]]...))
+}
+
+func (s *Server) getWebhook(r *http.Request, params map[string]string, body []byte) response {
+	index := s.findWebhook(params["site"], params["webhook"])
+	if index < 0 {
+		return notFound(r)
+	}
+
+	return ok(s.data.Webhooks[params["site"]][index])
+}
+
+func (s *Server) createWebhook(r *http.Request, params map[string]string, body []byte) response {
+	siteID := params["site"]
+	if s.data.site(siteID) == nil {
+		return notFound(r)
+	}
+
+	var req struct {
+		TriggerType string                 `json:"triggerType"`
+		URL         string                 `json:"url"`
+		Filter      map[string]interface{} `json:"filter"`
+	}
+	if res := decodeBody(r, body, &req); res != nil {
+		return *res
+	}
+
+	var problems []string
+	if req.TriggerType == "" {
+		problems = append(problems, "Field 'triggerType': Field is required")
+	} else if !triggerTypes[req.TriggerType] {
+		problems = append(problems, "Field 'triggerType': Invalid trigger type '"+req.TriggerType+"'")
+	}
+	if u, err := url.Parse(req.URL); req.URL == "" || err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		problems = append(problems, "Field 'url': Must be a valid http or https URL")
+	}
+	if len(problems) > 0 {
+		return validationError(r, problems...)
+	}
+
+	webhook := model.Webhook{
+		ID:          s.id(),
+		TriggerType: req.TriggerType,
+		TriggerID:   siteID,
+		Site:        siteID,
+		URL:         req.URL,
+		Filter:      req.Filter,
+		CreatedOn:   s.now(),
+	}
+	s.data.Webhooks[siteID] = append(s.data.Webhooks[siteID], webhook)
+
+	return ok(webhook)
+}
+
+func (s *Server) deleteWebhook(r *http.Request, params map[string]string, body []byte) response {
+	siteID := params["site"]
+	index := s.findWebhook(siteID, params["webhook"])
+	if index < 0 {
+		return notFound(r)
+	}
+
+	webhooks := s.data.Webhooks[siteID]
+	s.data.Webhooks[siteID] = append(webhooks[:index:index], webhooks[index+1:]...)
+
+	return ok(map[string]int{"deleted": 1})
+}
+
+// Emit delivers an event to the webhooks of a site registered for its
+// trigger type, e.g. to simulate a form submission.
+func (s *Server) Emit(siteID string, triggerType string, payload interface{}) {
+	s.mu.Lock()
+	deliveries := s.trigger(siteID, triggerType, payload)
+	s.mu.Unlock()
+
+	for _, d := range deliveries {
+		s.deliver(d)
+	}
+}
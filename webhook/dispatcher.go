@@ -0,0 +1,74 @@
+package webhook
+
+import (
+	"context"
+	"sync"
+
+	"github.com/nasrul21/go-webflow/model"
+)
+
+type HandlerFunc func(ctx context.Context, event model.WebhookEvent) error
+
+// Dispatcher routes webhook events to the handlers registered for their
+// trigger type. Events without a handler are ignored unless a publisher is
+// set, in which case every event is also decoded and published.
+type Dispatcher struct {
+	mu        sync.RWMutex
+	handlers  map[string][]HandlerFunc
+	publisher Publisher
+}
+
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{
+		handlers: map[string][]HandlerFunc{},
+	}
+}
+
+func (d *Dispatcher) Handle(triggerType string, handler HandlerFunc) *Dispatcher {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.handlers[triggerType] = append(d.handlers[triggerType], handler)
+	return d
+}
+
+// WithPublisher switches the dispatcher to fan-out mode: the typed event is
+// published before the handlers run, so a delivery refused with
+// ErrBackPressure and retried later has not run any handler yet. A delivery
+// retried because a handler failed is published again.
+func (d *Dispatcher) WithPublisher(publisher Publisher) *Dispatcher {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.publisher = publisher
+	return d
+}
+
+// Dispatch publishes the event when a publisher is set, then runs every
+// handler registered for the event trigger type in registration order,
+// stopping at the first error.
+func (d *Dispatcher) Dispatch(ctx context.Context, event model.WebhookEvent) error {
+	d.mu.RLock()
+	handlers := d.handlers[event.TriggerType]
+	publisher := d.publisher
+	d.mu.RUnlock()
+
+	if publisher != nil {
+		typed, err := Decode(event)
+		if err != nil {
+			return err
+		}
+
+		if err := publisher.Publish(ctx, typed); err != nil {
+			return err
+		}
+	}
+
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/nasrul21/go-webflow/webhook"
+	"github.com/stretchr/testify/mock"
+)
+
+// EventStoreMock is a testify mock of webhook.EventStore.
+type EventStoreMock struct {
+	mock.Mock
+}
+
+var _ webhook.EventStore = (*EventStoreMock)(nil)
+
+func (m *EventStoreMock) Append(event model.WebhookEvent) (bool, error) {
+	args := m.Called(event)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *EventStoreMock) Claim(id string) (bool, error) {
+	args := m.Called(id)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *EventStoreMock) Release(id string) error {
+	args := m.Called(id)
+
+	return args.Error(0)
+}
+
+func (m *EventStoreMock) MarkProcessed(id string) error {
+	args := m.Called(id)
+
+	return args.Error(0)
+}
+
+func (m *EventStoreMock) IsProcessed(id string) (bool, error) {
+	args := m.Called(id)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *EventStoreMock) Events() ([]model.WebhookEvent, error) {
+	args := m.Called()
+	res, _ := args.Get(0).([]model.WebhookEvent)
+
+	return res, args.Error(1)
+}
+
+// PublisherMock is a testify mock of webhook.Publisher.
+type PublisherMock struct {
+	mock.Mock
+}
+
+var _ webhook.Publisher = (*PublisherMock)(nil)
+
+func (m *PublisherMock) Publish(ctx context.Context, event webhook.TypedEvent) error {
+	args := m.Called(ctx, event)
+
+	return args.Error(0)
+}
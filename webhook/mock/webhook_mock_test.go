@@ -0,0 +1,39 @@
+package mock_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/nasrul21/go-webflow/webhook"
+	"github.com/nasrul21/go-webflow/webhook/mock"
+	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
+)
+
+func TestEventStoreMock(t *testing.T) {
+	id := webhook.EventID(model.TriggerSitePublish, []byte("1"))
+
+	publisher := new(mock.PublisherMock)
+	publisher.On("Publish", testifymock.Anything, testifymock.AnythingOfType("webhook.TypedEvent")).Return(webhook.ErrBackPressure).Once()
+
+	store := new(mock.EventStoreMock)
+	store.On("Append", testifymock.AnythingOfType("model.WebhookEvent")).Return(true, nil).Once()
+	store.On("Claim", id).Return(true, nil).Once()
+	store.On("Release", id).Return(nil).Once()
+
+	receiver := webhook.NewReceiver(webhook.NewDispatcher().WithPublisher(publisher)).
+		WithStore(store).
+		WithIDHeader("X-Delivery-Id")
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks?triggerType=site_publish", strings.NewReader(`{"site":"580e63e98c9a982ac9b8b741"}`))
+	req.Header.Set("X-Delivery-Id", "1")
+	rec := httptest.NewRecorder()
+	receiver.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	store.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
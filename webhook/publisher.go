@@ -0,0 +1,172 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nasrul21/go-webflow/model"
+)
+
+var ErrBackPressure = errors.New("webhook: publisher is full")
+
+// ErrNoSubscriber is returned by ChannelPublisher.Publish for trigger types
+// nobody subscribed to, so the delivery is not acknowledged and not lost.
+var ErrNoSubscriber = errors.New("webhook: no subscriber for trigger type")
+
+// DefaultPublishTimeout is how long the publishers of this package wait for
+// room in a full queue before giving up with ErrBackPressure.
+const DefaultPublishTimeout = 5 * time.Second
+
+// TypedEvent is a webhook event together with its payload decoded into the
+// model type for its trigger type.
+type TypedEvent struct {
+	model.WebhookEvent
+	Data interface{}
+}
+
+// Publisher hands webhook events over to downstream consumers. Publish must
+// block while consumers lag and return ErrBackPressure once it gives up, so
+// the delivery is not acknowledged and Webflow retries it later.
+type Publisher interface {
+	Publish(ctx context.Context, event TypedEvent) error
+}
+
+var payloadTypes = map[string]func() interface{}{
+	model.TriggerFormSubmission:        func() interface{} { return &model.FormSubmission{} },
+	model.TriggerSitePublish:           func() interface{} { return &model.SitePublish{} },
+	model.TriggerEcommNewOrder:         func() interface{} { return &model.Order{} },
+	model.TriggerEcommOrderChanged:     func() interface{} { return &model.Order{} },
+	model.TriggerEcommInventoryChanged: func() interface{} { return &model.InventoryChanged{} },
+	model.TriggerCollectionItemDeleted: func() interface{} { return &model.CollectionItemDeleted{} },
+}
+
+// Decode unmarshals the event payload into a pointer to the model type
+// registered for its trigger type. Trigger types without a model decode into
+// a map[string]interface{}.
+func Decode(event model.WebhookEvent) (TypedEvent, error) {
+	var data interface{}
+	if newPayload, ok := payloadTypes[event.TriggerType]; ok {
+		data = newPayload()
+	} else {
+		data = &map[string]interface{}{}
+	}
+
+	if err := json.Unmarshal(event.Payload, data); err != nil {
+		return TypedEvent{}, err
+	}
+	if m, ok := data.(*map[string]interface{}); ok {
+		data = *m
+	}
+
+	return TypedEvent{
+		WebhookEvent: event,
+		Data:         data,
+	}, nil
+}
+
+// send queues event on ch, waiting at most timeout for room. A timeout of
+// zero or less fails at once when ch is full.
+func send(ctx context.Context, ch chan<- TypedEvent, event TypedEvent, timeout time.Duration) error {
+	select {
+	case ch <- event:
+		return nil
+	default:
+	}
+
+	if timeout <= 0 {
+		return ErrBackPressure
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	select {
+	case ch <- event:
+		return nil
+	case <-ctx.Done():
+		return ErrBackPressure
+	}
+}
+
+// MemoryPublisher is a bounded in-memory FIFO queue of events.
+type MemoryPublisher struct {
+	// Timeout is how long Publish waits for room in a full queue; zero
+	// fails at once.
+	Timeout time.Duration
+	queue   chan TypedEvent
+}
+
+func NewMemoryPublisher(size int) *MemoryPublisher {
+	return &MemoryPublisher{
+		Timeout: DefaultPublishTimeout,
+		queue:   make(chan TypedEvent, size),
+	}
+}
+
+func (m *MemoryPublisher) Publish(ctx context.Context, event TypedEvent) error {
+	return send(ctx, m.queue, event, m.Timeout)
+}
+
+// Next blocks until an event is available or the context is done.
+func (m *MemoryPublisher) Next(ctx context.Context) (TypedEvent, error) {
+	select {
+	case event := <-m.queue:
+		return event, nil
+	case <-ctx.Done():
+		return TypedEvent{}, ctx.Err()
+	}
+}
+
+func (m *MemoryPublisher) Len() int {
+	return len(m.queue)
+}
+
+// ChannelPublisher publishes events onto one buffered channel per trigger
+// type. Events for trigger types nobody subscribed to are refused with
+// ErrNoSubscriber.
+type ChannelPublisher struct {
+	// Timeout is how long Publish waits for room in a full channel; zero
+	// fails at once.
+	Timeout  time.Duration
+	size     int
+	mu       sync.RWMutex
+	channels map[string]chan TypedEvent
+}
+
+func NewChannelPublisher(size int) *ChannelPublisher {
+	return &ChannelPublisher{
+		Timeout:  DefaultPublishTimeout,
+		size:     size,
+		channels: map[string]chan TypedEvent{},
+	}
+}
+
+// Subscribe returns the channel receiving events of the given trigger type.
+// Every call for the same trigger type returns the same channel.
+func (c *ChannelPublisher) Subscribe(triggerType string) <-chan TypedEvent {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch, ok := c.channels[triggerType]
+	if !ok {
+		ch = make(chan TypedEvent, c.size)
+		c.channels[triggerType] = ch
+	}
+
+	return ch
+}
+
+func (c *ChannelPublisher) Publish(ctx context.Context, event TypedEvent) error {
+	c.mu.RLock()
+	ch, ok := c.channels[event.TriggerType]
+	c.mu.RUnlock()
+
+	if !ok {
+		return ErrNoSubscriber
+	}
+
+	return send(ctx, ch, event, c.Timeout)
+}
@@ -0,0 +1,170 @@
+package webhook
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/nasrul21/go-webflow/model"
+)
+
+const maxBodySize int64 = 1 << 20
+
+var ErrMissingTriggerType = errors.New("webhook: missing trigger type")
+
+// Receiver is an http.Handler accepting Webflow webhook deliveries.
+//
+// The trigger type is read from the "triggerType" field of the body when
+// present, otherwise from the "triggerType" query parameter of the webhook
+// URL. A non 2xx response makes Webflow retry the delivery; when the
+// dispatcher publisher is full the receiver answers 503 with a Retry-After
+// header.
+//
+// Deliveries are deduplicated by event ID. Webflow sends no delivery
+// identifier, so the ID is derived from the trigger type and payload: a
+// retried delivery keeps its identity and is dispatched once. Payloads carry
+// the ID and timestamps of what changed, so distinct events rarely share a
+// payload. When IDHeader is set and the request carries that header, the ID
+// is derived from the header value instead.
+type Receiver struct {
+	Dispatcher *Dispatcher
+	Store      EventStore
+	RetryAfter time.Duration
+	// IDHeader optionally names a request header identifying a delivery,
+	// e.g. one set by a proxy in front of the receiver. It overrides the ID
+	// derived from the payload.
+	IDHeader string
+}
+
+func NewReceiver(dispatcher *Dispatcher) *Receiver {
+	return &Receiver{
+		Dispatcher: dispatcher,
+		RetryAfter: 30 * time.Second,
+	}
+}
+
+// WithStore makes the receiver persist every delivery and skip events that
+// were already processed successfully or are being dispatched by a
+// concurrent request.
+func (rc *Receiver) WithStore(store EventStore) *Receiver {
+	rc.Store = store
+	return rc
+}
+
+// WithIDHeader makes the receiver deduplicate deliveries by the given
+// request header when present instead of by payload.
+func (rc *Receiver) WithIDHeader(name string) *Receiver {
+	rc.IDHeader = name
+	return rc
+}
+
+func (rc *Receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	event, err := NewEvent(r.URL.Query().Get("triggerType"), body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if rc.IDHeader != "" {
+		if id := r.Header.Get(rc.IDHeader); id != "" {
+			event.ID = EventID(event.TriggerType, []byte(id))
+		}
+	}
+
+	if err := rc.process(r, event); err != nil {
+		if errors.Is(err, ErrBackPressure) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(rc.RetryAfter.Seconds())))
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (rc *Receiver) process(r *http.Request, event model.WebhookEvent) error {
+	if rc.Store == nil {
+		return rc.Dispatcher.Dispatch(r.Context(), event)
+	}
+
+	if _, err := rc.Store.Append(event); err != nil {
+		return err
+	}
+
+	claimed, err := rc.Store.Claim(event.ID)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+
+	if err := rc.Dispatcher.Dispatch(r.Context(), event); err != nil {
+		_ = rc.Store.Release(event.ID)
+		return err
+	}
+
+	return rc.Store.MarkProcessed(event.ID)
+}
+
+// NewEvent builds an event from a raw delivery body. The body does not
+// identify the delivery, so the event ID is derived from the trigger type
+// and payload and redeliveries share the same identity.
+func NewEvent(triggerType string, body []byte) (model.WebhookEvent, error) {
+	var envelope struct {
+		TriggerType string          `json:"triggerType"`
+		Payload     json.RawMessage `json:"payload"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return model.WebhookEvent{}, err
+	}
+
+	payload := json.RawMessage(body)
+	if envelope.TriggerType != "" {
+		triggerType = envelope.TriggerType
+		if len(envelope.Payload) > 0 {
+			payload = envelope.Payload
+		}
+	}
+
+	if triggerType == "" {
+		return model.WebhookEvent{}, ErrMissingTriggerType
+	}
+
+	return model.WebhookEvent{
+		ID:          EventID(triggerType, payload),
+		TriggerType: triggerType,
+		Payload:     payload,
+		ReceivedAt:  time.Now().UTC(),
+	}, nil
+}
+
+// EventID derives an event ID from the trigger type and a key identifying
+// the delivery.
+func EventID(triggerType string, key []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(triggerType))
+	hash.Write([]byte{':'})
+	hash.Write(key)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
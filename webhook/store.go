@@ -0,0 +1,268 @@
+package webhook
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/nasrul21/go-webflow/model"
+)
+
+// EventStore persists raw webhook deliveries so duplicates can be detected
+// and stored events replayed later.
+type EventStore interface {
+	// Append stores the event unless one with the same ID already exists and
+	// reports whether it was added.
+	Append(event model.WebhookEvent) (bool, error)
+	// Claim atomically reserves a stored event for dispatch and reports
+	// whether the caller got it. It fails to claim events that were already
+	// processed or are claimed by someone else, so concurrent redeliveries
+	// are dispatched once.
+	Claim(id string) (bool, error)
+	// Release gives up a claim after a failed dispatch so the event can be
+	// claimed again.
+	Release(id string) error
+	// MarkProcessed records a successful dispatch and drops the claim.
+	MarkProcessed(id string) error
+	IsProcessed(id string) (bool, error)
+	// Events returns every stored event in the order it was first received.
+	Events() ([]model.WebhookEvent, error)
+}
+
+type MemoryStore struct {
+	mu        sync.RWMutex
+	events    []model.WebhookEvent
+	index     map[string]int
+	processed map[string]bool
+	claimed   map[string]bool
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		index:     map[string]int{},
+		processed: map[string]bool{},
+		claimed:   map[string]bool{},
+	}
+}
+
+func (m *MemoryStore) Append(event model.WebhookEvent) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.append(event), nil
+}
+
+func (m *MemoryStore) append(event model.WebhookEvent) bool {
+	if _, ok := m.index[event.ID]; ok {
+		return false
+	}
+
+	m.index[event.ID] = len(m.events)
+	m.events = append(m.events, event)
+	return true
+}
+
+func (m *MemoryStore) Claim(id string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.processed[id] || m.claimed[id] {
+		return false, nil
+	}
+
+	m.claimed[id] = true
+	return true, nil
+}
+
+func (m *MemoryStore) Release(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.claimed, id)
+	return nil
+}
+
+func (m *MemoryStore) MarkProcessed(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.processed[id] = true
+	delete(m.claimed, id)
+	return nil
+}
+
+func (m *MemoryStore) IsProcessed(id string) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.processed[id], nil
+}
+
+func (m *MemoryStore) Events() ([]model.WebhookEvent, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	events := make([]model.WebhookEvent, len(m.events))
+	copy(events, m.events)
+	return events, nil
+}
+
+type fileRecord struct {
+	Event     *model.WebhookEvent `json:"event,omitempty"`
+	Processed string              `json:"processed,omitempty"`
+}
+
+// FileStore is an append-only JSON Lines event store. Each line holds either
+// a received event or a marker that an event was processed; the state is
+// rebuilt from the file when it is opened. Claims are kept in memory only, so
+// events claimed when the process stopped are pending again after a restart.
+type FileStore struct {
+	mu    sync.Mutex
+	file  *os.File
+	cache *MemoryStore
+}
+
+func NewFileStore(path string) (*FileStore, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := NewMemoryStore()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), int(maxBodySize)*2)
+	for scanner.Scan() {
+		var record fileRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			file.Close()
+			return nil, err
+		}
+
+		if record.Event != nil {
+			cache.append(*record.Event)
+		}
+		if record.Processed != "" {
+			cache.processed[record.Processed] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &FileStore{
+		file:  file,
+		cache: cache,
+	}, nil
+}
+
+func (f *FileStore) Append(event model.WebhookEvent) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.cache.index[event.ID]; ok {
+		return false, nil
+	}
+
+	if err := f.write(fileRecord{Event: &event}); err != nil {
+		return false, err
+	}
+
+	return f.cache.append(event), nil
+}
+
+func (f *FileStore) Claim(id string) (bool, error) {
+	return f.cache.Claim(id)
+}
+
+func (f *FileStore) Release(id string) error {
+	return f.cache.Release(id)
+}
+
+func (f *FileStore) MarkProcessed(id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.cache.processed[id] {
+		return nil
+	}
+
+	if err := f.write(fileRecord{Processed: id}); err != nil {
+		return err
+	}
+
+	return f.cache.MarkProcessed(id)
+}
+
+func (f *FileStore) IsProcessed(id string) (bool, error) {
+	return f.cache.IsProcessed(id)
+}
+
+func (f *FileStore) Events() ([]model.WebhookEvent, error) {
+	return f.cache.Events()
+}
+
+func (f *FileStore) Close() error {
+	return f.file.Close()
+}
+
+func (f *FileStore) write(record fileRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.file.Write(append(line, '\n'))
+	return err
+}
+
+// Replay dispatches every stored event again, regardless of whether it was
+// processed before, and marks each one processed on success.
+func Replay(ctx context.Context, store EventStore, dispatcher *Dispatcher) error {
+	return replay(ctx, store, dispatcher, false)
+}
+
+// ReplayPending dispatches only the stored events that were never processed
+// successfully, e.g. after a handler outage. Events claimed by a receiver at
+// the same time are skipped.
+func ReplayPending(ctx context.Context, store EventStore, dispatcher *Dispatcher) error {
+	return replay(ctx, store, dispatcher, true)
+}
+
+func replay(ctx context.Context, store EventStore, dispatcher *Dispatcher, pendingOnly bool) error {
+	events, err := store.Events()
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if pendingOnly {
+			claimed, err := store.Claim(event.ID)
+			if err != nil {
+				return err
+			}
+			if !claimed {
+				continue
+			}
+		}
+
+		if err := dispatcher.Dispatch(ctx, event); err != nil {
+			if pendingOnly {
+				_ = store.Release(event.ID)
+			}
+			return err
+		}
+
+		if err := store.MarkProcessed(event.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
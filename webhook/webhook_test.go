@@ -0,0 +1,399 @@
+package webhook_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nasrul21/go-webflow/model"
+	"github.com/nasrul21/go-webflow/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+const formSubmission = `{"_id":"5d93ba5e38c6b0160ab711d3","name":"Contact","site":"580e63e98c9a982ac9b8b741","data":{"email":"some@email.com"}}`
+
+func deliver(handler http.Handler, target string, body string) *httptest.ResponseRecorder {
+	return deliverWithID(handler, target, body, "")
+}
+
+func deliverWithID(handler http.Handler, target string, body string, id string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	if id != "" {
+		req.Header.Set("X-Delivery-Id", id)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNewEvent(t *testing.T) {
+	testcases := []struct {
+		desc                string
+		triggerType         string
+		body                string
+		expectedTriggerType string
+		expectedPayload     string
+		expectedErr         error
+	}{
+		{
+			desc:                "should use trigger type from url",
+			triggerType:         model.TriggerFormSubmission,
+			body:                formSubmission,
+			expectedTriggerType: model.TriggerFormSubmission,
+			expectedPayload:     formSubmission,
+		},
+		{
+			desc:                "should unwrap envelope",
+			body:                fmt.Sprintf(`{"triggerType":"form_submission","payload":%s}`, formSubmission),
+			expectedTriggerType: model.TriggerFormSubmission,
+			expectedPayload:     formSubmission,
+		},
+		{
+			desc:        "should return error without trigger type",
+			body:        formSubmission,
+			expectedErr: webhook.ErrMissingTriggerType,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			event, err := webhook.NewEvent(tc.triggerType, []byte(tc.body))
+
+			assert.Equal(t, tc.expectedErr, err)
+			if tc.expectedErr == nil {
+				assert.Equal(t, tc.expectedTriggerType, event.TriggerType)
+				assert.JSONEq(t, tc.expectedPayload, string(event.Payload))
+				assert.Equal(t, webhook.EventID(tc.expectedTriggerType, event.Payload), event.ID)
+			}
+		})
+	}
+}
+
+func TestReceiver(t *testing.T) {
+	var received []model.WebhookEvent
+	dispatcher := webhook.NewDispatcher().Handle(model.TriggerFormSubmission, func(ctx context.Context, event model.WebhookEvent) error {
+		received = append(received, event)
+		return nil
+	})
+	receiver := webhook.NewReceiver(dispatcher)
+
+	rec := deliver(receiver, "/webhooks?triggerType=form_submission", formSubmission)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = deliver(receiver, "/webhooks", formSubmission)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = deliver(receiver, "/webhooks?triggerType=form_submission", "not json")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/webhooks", nil)
+	rec = httptest.NewRecorder()
+	receiver.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	assert.Len(t, received, 1)
+	assert.JSONEq(t, formSubmission, string(received[0].Payload))
+}
+
+func TestReceiverWithStore(t *testing.T) {
+	calls := 0
+	fail := true
+	dispatcher := webhook.NewDispatcher().Handle(model.TriggerFormSubmission, func(ctx context.Context, event model.WebhookEvent) error {
+		calls++
+		if fail {
+			return fmt.Errorf("some error")
+		}
+		return nil
+	})
+	store := webhook.NewMemoryStore()
+	receiver := webhook.NewReceiver(dispatcher).WithStore(store)
+
+	rec := deliver(receiver, "/webhooks?triggerType=form_submission", formSubmission)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	fail = false
+	rec = deliver(receiver, "/webhooks?triggerType=form_submission", formSubmission)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = deliver(receiver, "/webhooks?triggerType=form_submission", formSubmission)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	events, err := store.Events()
+	assert.Nil(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, 2, calls)
+
+	// An ID header overrides the payload as the delivery identity.
+	receiver.WithIDHeader("X-Delivery-Id")
+	rec = deliverWithID(receiver, "/webhooks?triggerType=form_submission", formSubmission, "2")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec = deliverWithID(receiver, "/webhooks?triggerType=form_submission", formSubmission, "2")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	events, err = store.Events()
+	assert.Nil(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, 3, calls)
+}
+
+func TestReceiverConcurrentRedelivery(t *testing.T) {
+	var calls int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	dispatcher := webhook.NewDispatcher().Handle(model.TriggerFormSubmission, func(ctx context.Context, event model.WebhookEvent) error {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			close(started)
+			<-release
+		}
+		return nil
+	})
+	receiver := webhook.NewReceiver(dispatcher).WithStore(webhook.NewMemoryStore())
+
+	done := make(chan int)
+	go func() {
+		done <- deliver(receiver, "/webhooks?triggerType=form_submission", formSubmission).Code
+	}()
+	<-started
+
+	rec := deliver(receiver, "/webhooks?triggerType=form_submission", formSubmission)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	close(release)
+	assert.Equal(t, http.StatusOK, <-done)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestMemoryStoreClaim(t *testing.T) {
+	store := webhook.NewMemoryStore()
+
+	claimed, err := store.Claim("1")
+	assert.Nil(t, err)
+	assert.True(t, claimed)
+
+	claimed, _ = store.Claim("1")
+	assert.False(t, claimed)
+
+	assert.Nil(t, store.Release("1"))
+	claimed, _ = store.Claim("1")
+	assert.True(t, claimed)
+
+	assert.Nil(t, store.MarkProcessed("1"))
+	claimed, _ = store.Claim("1")
+	assert.False(t, claimed)
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	store, err := webhook.NewFileStore(path)
+	assert.Nil(t, err)
+
+	first, _ := webhook.NewEvent(model.TriggerFormSubmission, []byte(formSubmission))
+	second, _ := webhook.NewEvent(model.TriggerSitePublish, []byte(`{"site":"580e63e98c9a982ac9b8b741"}`))
+
+	added, err := store.Append(first)
+	assert.Nil(t, err)
+	assert.True(t, added)
+
+	added, err = store.Append(first)
+	assert.Nil(t, err)
+	assert.False(t, added)
+
+	_, _ = store.Append(second)
+	assert.Nil(t, store.MarkProcessed(first.ID))
+	assert.Nil(t, store.Close())
+
+	store, err = webhook.NewFileStore(path)
+	assert.Nil(t, err)
+	defer store.Close()
+
+	events, err := store.Events()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{first.ID, second.ID}, []string{events[0].ID, events[1].ID})
+	assert.JSONEq(t, formSubmission, string(events[0].Payload))
+
+	processed, _ := store.IsProcessed(first.ID)
+	assert.True(t, processed)
+	processed, _ = store.IsProcessed(second.ID)
+	assert.False(t, processed)
+}
+
+func TestReplay(t *testing.T) {
+	first, _ := webhook.NewEvent(model.TriggerFormSubmission, []byte(formSubmission))
+	second, _ := webhook.NewEvent(model.TriggerSitePublish, []byte(`{"site":"580e63e98c9a982ac9b8b741"}`))
+
+	testcases := []struct {
+		desc        string
+		replay      func(ctx context.Context, store webhook.EventStore, dispatcher *webhook.Dispatcher) error
+		expectedIDs []string
+	}{
+		{
+			desc:        "should replay all events",
+			replay:      webhook.Replay,
+			expectedIDs: []string{first.ID, second.ID},
+		},
+		{
+			desc:        "should replay pending events",
+			replay:      webhook.ReplayPending,
+			expectedIDs: []string{second.ID},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			store := webhook.NewMemoryStore()
+			_, _ = store.Append(first)
+			_, _ = store.Append(second)
+			_ = store.MarkProcessed(first.ID)
+
+			var replayed []string
+			record := func(ctx context.Context, event model.WebhookEvent) error {
+				replayed = append(replayed, event.ID)
+				return nil
+			}
+			dispatcher := webhook.NewDispatcher().
+				Handle(model.TriggerFormSubmission, record).
+				Handle(model.TriggerSitePublish, record)
+
+			err := tc.replay(context.Background(), store, dispatcher)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedIDs, replayed)
+
+			processed, _ := store.IsProcessed(second.ID)
+			assert.True(t, processed)
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	testcases := []struct {
+		desc         string
+		triggerType  string
+		body         string
+		expectedData interface{}
+	}{
+		{
+			desc:        "should decode form submission",
+			triggerType: model.TriggerFormSubmission,
+			body:        `{"_id":"5d93ba5e38c6b0160ab711d3","name":"Contact","site":"580e63e98c9a982ac9b8b741","data":{"email":"some@email.com"}}`,
+			expectedData: &model.FormSubmission{
+				ID:   "5d93ba5e38c6b0160ab711d3",
+				Name: "Contact",
+				Site: "580e63e98c9a982ac9b8b741",
+				Data: map[string]interface{}{"email": "some@email.com"},
+			},
+		},
+		{
+			desc:        "should decode inventory changed",
+			triggerType: model.TriggerEcommInventoryChanged,
+			body:        `{"_id":"5eb9fd05caef491eb9757183","orderLimit":null,"quantity":8,"inventoryType":"finite"}`,
+			expectedData: &model.InventoryChanged{
+				ID:            "5eb9fd05caef491eb9757183",
+				Quantity:      8,
+				InventoryType: "finite",
+			},
+		},
+		{
+			desc:         "should decode unknown trigger type into map",
+			triggerType:  model.TriggerCollectionItemCreated,
+			body:         `{"_id":"580e64008c9a982ac9b8b754","name":"Item"}`,
+			expectedData: map[string]interface{}{"_id": "580e64008c9a982ac9b8b754", "name": "Item"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			event, _ := webhook.NewEvent(tc.triggerType, []byte(tc.body))
+
+			typed, err := webhook.Decode(event)
+
+			assert.Nil(t, err)
+			assert.Equal(t, event, typed.WebhookEvent)
+			assert.Equal(t, tc.expectedData, typed.Data)
+		})
+	}
+}
+
+func TestReceiverWithChannelPublisher(t *testing.T) {
+	publisher := webhook.NewChannelPublisher(1)
+	publisher.Timeout = 10 * time.Millisecond
+	forms := publisher.Subscribe(model.TriggerFormSubmission)
+
+	store := webhook.NewMemoryStore()
+	receiver := webhook.NewReceiver(webhook.NewDispatcher().WithPublisher(publisher)).WithStore(store)
+
+	rec := deliver(receiver, "/webhooks?triggerType=form_submission", formSubmission)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	second := `{"_id":"5d93ba5e38c6b0160ab711d4","name":"Contact"}`
+	rec = deliver(receiver, "/webhooks?triggerType=form_submission", second)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "30", rec.Header().Get("Retry-After"))
+
+	rec = deliver(receiver, "/webhooks?triggerType=site_publish", `{"site":"580e63e98c9a982ac9b8b741"}`)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	processed, _ := store.IsProcessed(webhook.EventID(model.TriggerSitePublish, []byte(`{"site":"580e63e98c9a982ac9b8b741"}`)))
+	assert.False(t, processed)
+
+	event := <-forms
+	assert.Equal(t, "5d93ba5e38c6b0160ab711d3", event.Data.(*model.FormSubmission).ID)
+
+	rec = deliver(receiver, "/webhooks?triggerType=form_submission", second)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	event = <-forms
+	assert.Equal(t, "5d93ba5e38c6b0160ab711d4", event.Data.(*model.FormSubmission).ID)
+}
+
+func TestMemoryPublisher(t *testing.T) {
+	publisher := webhook.NewMemoryPublisher(1)
+	first, _ := webhook.Decode(model.WebhookEvent{ID: "1", TriggerType: model.TriggerSitePublish, Payload: []byte(`{}`)})
+	second, _ := webhook.Decode(model.WebhookEvent{ID: "2", TriggerType: model.TriggerSitePublish, Payload: []byte(`{}`)})
+
+	assert.Nil(t, publisher.Publish(context.Background(), first))
+	assert.Equal(t, 1, publisher.Len())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, webhook.ErrBackPressure, publisher.Publish(ctx, second))
+
+	event, err := publisher.Next(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "1", event.ID)
+
+	_, err = publisher.Next(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	publisher.Timeout = 0
+	assert.Nil(t, publisher.Publish(context.Background(), first))
+	assert.Equal(t, webhook.ErrBackPressure, publisher.Publish(context.Background(), second))
+}
+
+func TestDispatchPublishesBeforeHandlers(t *testing.T) {
+	publisher := webhook.NewMemoryPublisher(1)
+	publisher.Timeout = 0
+
+	calls := 0
+	dispatcher := webhook.NewDispatcher().
+		WithPublisher(publisher).
+		Handle(model.TriggerSitePublish, func(ctx context.Context, event model.WebhookEvent) error {
+			calls++
+			return nil
+		})
+	event := model.WebhookEvent{ID: "1", TriggerType: model.TriggerSitePublish, Payload: []byte(`{}`)}
+
+	assert.Nil(t, dispatcher.Dispatch(context.Background(), event))
+	assert.Equal(t, webhook.ErrBackPressure, dispatcher.Dispatch(context.Background(), event))
+	assert.Equal(t, 1, calls)
+
+	_, _ = publisher.Next(context.Background())
+	assert.Nil(t, dispatcher.Dispatch(context.Background(), event))
+	assert.Equal(t, 2, calls)
+}